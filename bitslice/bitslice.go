@@ -102,6 +102,13 @@ func (bs T) Popcnt() int {
 	return pop
 }
 
+// Or sets every bit that is set in x. Note that x must not be longer than bs.
+func (bs T) Or(x T) {
+	for i, n := range x {
+		bs[i] |= n // bs[i] OR= x[i]
+	}
+}
+
 // Reset clears all bits.
 func (bs T) Reset() {
 	for i := range bs {
@@ -161,3 +161,21 @@ func TestBitSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestBitSliceOr(t *testing.T) {
+	data := []struct {
+		bs, x, out T
+	}{
+		{bs: T{0x0}, x: T{0x0}, out: T{0x0}},
+		{bs: T{0x5}, x: T{0x3}, out: T{0x7}},
+		{bs: T{0x1, 0x2}, x: T{0x8}, out: T{0x9, 0x2}},
+		{bs: T{0x1, 0x2}, x: T{}, out: T{0x1, 0x2}},
+	}
+
+	for _, row := range data {
+		row.bs.Or(row.x)
+		if !reflect.DeepEqual(row.bs, row.out) {
+			t.Errorf("%v != %v", row.bs, row.out)
+		}
+	}
+}
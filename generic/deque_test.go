@@ -0,0 +1,177 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Random operations on a deque are checked against a slice
+func TestGenericTypeDeque(t *testing.T) {
+	type T = GenericType
+
+	r := rand.New(rand.NewSource(1))
+	buf := make([]T, 8)
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(8)
+		d := NewGenericTypeDeque(size)
+		if i%2 == 0 {
+			d = NewGenericTypeDequeWithBuffer(make([]T, size))
+		}
+
+		var model []T
+
+		for j := 0; j < 200; j++ {
+			switch r.Intn(10) {
+			case 0:
+				d.PushFront(j)
+				model = append([]T{j}, model...)
+			case 1:
+				d.PushBack(j)
+				model = append(model, j)
+			case 2:
+				if len(model) > 0 {
+					if x := d.PopFront(); x != model[0] {
+						t.Errorf("%v != %v", x, model[0])
+					}
+					model = model[1:]
+				}
+			case 3:
+				if len(model) > 0 {
+					if x := d.PopBack(); x != model[len(model)-1] {
+						t.Errorf("%v != %v", x, model[len(model)-1])
+					}
+					model = model[:len(model)-1]
+				}
+			case 4:
+				src := buf[:r.Intn(len(buf))]
+				for k := range src {
+					src[k] = j + k
+				}
+				d.PushFrontSlice(src)
+				model = append(append([]T{}, src...), model...)
+			case 5:
+				src := buf[:r.Intn(len(buf))]
+				for k := range src {
+					src[k] = j + k
+				}
+				d.PushBackSlice(src)
+				model = append(model, src...)
+			case 6:
+				n := d.PopFrontSlice(buf[:r.Intn(len(buf))])
+				if n > 0 && !reflect.DeepEqual(buf[:n], model[:n]) {
+					t.Errorf("%v != %v", buf[:n], model[:n])
+				}
+				model = model[n:]
+			case 7:
+				n := d.PopBackSlice(buf[:r.Intn(len(buf))])
+				for k := 0; k < n; k++ {
+					if buf[k] != model[len(model)-1-k] {
+						t.Errorf("%v != %v", buf[k], model[len(model)-1-k])
+					}
+				}
+				model = model[:len(model)-n]
+			case 8:
+				if len(model) > 0 {
+					k := r.Intn(len(model))
+					d.Set(k, -j)
+					model[k] = -j
+				}
+			case 9:
+				if r.Intn(10) == 0 {
+					d.Reset()
+					model = model[:0]
+				}
+			}
+
+			if d.Len() != len(model) {
+				t.Errorf("%v != %v", d.Len(), len(model))
+			}
+			if d.Len() > d.Cap() {
+				t.Errorf("%v > %v", d.Len(), d.Cap())
+			}
+			for k := range model {
+				if d.At(k) != model[k] {
+					t.Errorf("%v != %v", d.At(k), model[k])
+				}
+			}
+			if len(model) > 0 && (d.Front() != model[0] || d.Back() != model[len(model)-1]) {
+				t.Errorf("%v != %v", []T{d.Front(), d.Back()}, []T{model[0], model[len(model)-1]})
+			}
+		}
+	}
+}
+
+func TestGenericTypeDequeWithoutAutoGrow(t *testing.T) {
+	type T = GenericType
+
+	buf := make([]T, 4)
+	d := NewGenericTypeDequeWithBuffer(buf)
+	d.SetAutoGrow(false)
+
+	d.PushBackSlice([]T{2, 3})
+	d.PushFront(1)
+	d.PushBack(4)
+
+	if !reflect.DeepEqual(buf, []T{2, 3, 4, 1}) {
+		t.Errorf("%v != %v", buf, []T{2, 3, 4, 1})
+	}
+	if d.Cap() != 4 {
+		t.Errorf("%v != %v", d.Cap(), 4)
+	}
+
+	// Elements that do not fit are dropped
+	d.PushFront(0)
+	d.PushBack(5)
+	if d.Len() != 4 || !reflect.DeepEqual(buf, []T{2, 3, 4, 1}) {
+		t.Errorf("%v != %v", buf, []T{2, 3, 4, 1})
+	}
+
+	d.PopFront()
+	d.PopBack()
+	d.PushFrontSlice([]T{-2, -1, 0})
+	if x := d.PopFront(); x != -1 || d.Len() != 3 {
+		t.Errorf("%v != %v", x, -1)
+	}
+	d.PushBackSlice([]T{4, 5, 6})
+	if x := d.PopBack(); x != 4 || d.Len() != 3 {
+		t.Errorf("%v != %v", x, 4)
+	}
+	for k, x := range []T{0, 2, 3} {
+		if d.At(k) != x {
+			t.Errorf("%v != %v", d.At(k), x)
+		}
+	}
+
+	// A deque without a buffer drops everything
+	empty := NewGenericTypeDequeWithBuffer(nil)
+	empty.SetAutoGrow(false)
+	empty.PushFront(1)
+	empty.PushBackSlice([]T{1, 2})
+	if empty.Len() != 0 {
+		t.Errorf("%v != %v", empty.Len(), 0)
+	}
+
+	for _, f := range []func(){
+		func() { d.At(3) },
+		func() { d.At(-1) },
+		func() { d.Set(3, 0) },
+		func() { d.Reset(); d.PopFront() },
+		func() { d.Reset(); d.PopBack() },
+		func() { empty.Front() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -4,6 +4,10 @@
 
 // Package generic provides generic templates for common data structures and
 // functions via https://github.com/cheekybits/genny/generic
+//
+// Type-parameterized equivalents of the templates, such as Queue[T], Stack[T],
+// Packed2DBuilder[T], Sort, Min, and Max, are also provided. They have the
+// same semantics as the generated implementations in package impl.
 package generic
 
 import "github.com/cheekybits/genny/generic"
@@ -12,8 +16,10 @@ type GenericType generic.Type
 type GenericNumber generic.Number
 type ComparableType interface{ Less(x *ComparableType) bool } // generic.Type
 
+//go:generate genny -pkg=impl -in=deque.go -out=impl/deque.go gen GenericType=int
 //go:generate genny -pkg=impl -in=math.go -out=impl/math.go gen GenericNumber=int
 //go:generate genny -pkg=impl -in=packed2dbuilder.go -out=impl/packed2dbuilder.go gen GenericType=int
 //go:generate genny -pkg=impl -in=queue.go -out=impl/queue.go gen GenericType=int
 //go:generate genny -pkg=impl -in=quicksort.go -out=impl/quicksort.go gen GenericNumber=int
 //go:generate genny -pkg=impl -in=stack.go -out=impl/stack.go gen GenericType=int
+//go:generate genny -pkg=impl -in=unionfind.go -out=impl/unionfind.go gen GenericNumber=int
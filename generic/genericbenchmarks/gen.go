@@ -1,5 +1,5 @@
 // Package genericbenchmarks benchmarks the generic implementations against
-// the standard library.
+// the standard library, and against their type-parameterized equivalents.
 package genericbenchmarks
 
 //go:generate genny -pkg=genericbenchmarks -in=../math.go -out=math.go gen GenericNumber=int
@@ -4,21 +4,29 @@
 
 package genericbenchmarks
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/guns/golibs/generic"
+)
 
 var numbers = []int{45, 76, 82, 77, 60, 29, 40, 94, 32, 14, 12, 95, 92, 36, 38, 70, 43, 90, 20, 46, 8, 71, 80, 30, 67, 33, 47, 74, 35, 61, 25, 98, 91, 63, 42, 54, 5, 55, 23, 41, 11, 34, 68, 99, 15, 78, 31, 6, 26, 56, 83, 57, 58, 87, 28, 21, 73, 13, 10, 44, 86, 88, 75, 96, 52, 65, 59, 27, 93, 66, 17, 69, 3, 62, 81, 53, 7, 72, 1, 22, 16, 37, 85, 18, 50, 19, 2, 4, 0, 9, 64, 49, 24, 39, 97, 84, 48, 89, 51, 79}
 
 // goos: linux
 // goarch: amd64
 // pkg: github.com/guns/golibs/generic/genericbenchmarks
-// BenchmarkMinInt2-4              2000000000               0.36 ns/op            0 B/op          0 allocs/op
-// BenchmarkMinIntV2-4             50000000                22.7 ns/op             0 B/op          0 allocs/op
-// BenchmarkMinInt3-4              100000000               16.0 ns/op             0 B/op          0 allocs/op
-// BenchmarkMinIntV3-4             50000000                31.5 ns/op             0 B/op          0 allocs/op
-// BenchmarkMinIntLoop-4           20000000                79.5 ns/op             0 B/op          0 allocs/op
-// BenchmarkMinIntSlice-4          20000000               107 ns/op               0 B/op          0 allocs/op
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkMinInt2                      1000000000        0.4290 ns/op           0 B/op           0 allocs/op
+// BenchmarkMinIntV2                      493555381         2.076 ns/op           0 B/op           0 allocs/op
+// BenchmarkMinInt3                      1000000000        0.4637 ns/op           0 B/op           0 allocs/op
+// BenchmarkMinIntV3                      100000000         11.72 ns/op           0 B/op           0 allocs/op
+// BenchmarkMinIntLoop                     28278499         55.97 ns/op           0 B/op           0 allocs/op
+// BenchmarkMinIntSlice                    19708929         60.32 ns/op           0 B/op           0 allocs/op
+// BenchmarkTypeParamMin                 1000000000        0.6348 ns/op           0 B/op           0 allocs/op
+// BenchmarkTypeParamMin3                1000000000        0.4433 ns/op           0 B/op           0 allocs/op
+// BenchmarkTypeParamMinSlice              30048945         57.41 ns/op           0 B/op           0 allocs/op
 // PASS
-// ok      github.com/guns/golibs/generic/genericbenchmarks        9.082s
+// ok      github.com/guns/golibs/generic/genericbenchmarks        23.557s
 
 func BenchmarkMinInt2(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -61,3 +69,21 @@ func BenchmarkMinIntSlice(b *testing.B) {
 		_ = MinIntSlice(numbers)
 	}
 }
+
+func BenchmarkTypeParamMin(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		generic.Min(i%len(numbers), (i+1)%len(numbers))
+	}
+}
+
+func BenchmarkTypeParamMin3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		generic.Min3(i%len(numbers), (i+1)%len(numbers), (i+2)%len(numbers))
+	}
+}
+
+func BenchmarkTypeParamMinSlice(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = generic.MinSlice(numbers)
+	}
+}
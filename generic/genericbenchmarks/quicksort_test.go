@@ -4,6 +4,8 @@ import (
 	"math/rand"
 	"sort"
 	"testing"
+
+	"github.com/guns/golibs/generic"
 )
 
 func randslice(n int) []int {
@@ -133,12 +135,14 @@ var names = []string{
 // goos: linux
 // goarch: amd64
 // pkg: github.com/guns/golibs/generic/genericbenchmarks
-// BenchmarkSortInts-4                                30000             62906 ns/op              32 B/op          1 allocs/op
-// BenchmarkQuicksortIntSlice-4                       50000             26903 ns/op               0 B/op          0 allocs/op
-// BenchmarkSortSortPersonSlice-4                     10000            203544 ns/op              32 B/op          1 allocs/op
-// BenchmarkQuicksortPersonSliceMethod-4              10000            175966 ns/op               0 B/op          0 allocs/op
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkSortInts                          91723         12727 ns/op           0 B/op           0 allocs/op
+// BenchmarkQuicksortIntSlice                 57105         18370 ns/op           0 B/op           0 allocs/op
+// BenchmarkTypeParamSortInts                 80397         14561 ns/op           0 B/op           0 allocs/op
+// BenchmarkSortSortPersonSlice                8176        145965 ns/op          24 B/op           1 allocs/op
+// BenchmarkQuicksortPersonSliceMethod         7095        151655 ns/op           0 B/op           0 allocs/op
 // PASS
-// ok      github.com/guns/golibs/generic/genericbenchmarks        8.186s
+// ok      github.com/guns/golibs/generic/genericbenchmarks        23.557s
 
 const slicelen = 1000
 
@@ -162,6 +166,16 @@ func BenchmarkQuicksortIntSlice(b *testing.B) {
 		QuicksortIntSlice(s)
 	}
 }
+func BenchmarkTypeParamSortInts(b *testing.B) {
+	r := randslice(slicelen)
+	s := make([]int, len(r))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(s, r)
+		generic.Sort(s)
+	}
+}
 func BenchmarkSortSortPersonSlice(b *testing.B) {
 	r := randPersonSlice(slicelen)
 	s := make([]Person, len(r))
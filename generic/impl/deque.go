@@ -0,0 +1,263 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package impl
+
+import "math/bits"
+
+// IntDeque is an optionally auto-growing double-ended queue backed by
+// a ring buffer.
+type IntDeque struct {
+	a        []int
+	head     int // Index of the front element
+	n        int // Number of elements
+	autoGrow bool
+}
+
+// NewIntDeque returns a new auto-growing deque that can accommodate
+// at least size items.
+func NewIntDeque(size int) *IntDeque {
+	return NewIntDequeWithBuffer(
+		make([]int, 1<<uint(bits.Len(uint(size-1)))),
+	)
+}
+
+// NewIntDequeWithBuffer returns a new auto-growing deque that wraps
+// the provided buffer, which is never resliced beyond its current length.
+func NewIntDequeWithBuffer(buf []int) *IntDeque {
+	return &IntDeque{
+		a:        buf,
+		autoGrow: true,
+	}
+}
+
+// SetAutoGrow enables or disables auto-growing. Elements that would
+// overflow a deque that does not auto-grow are dropped.
+func (d *IntDeque) SetAutoGrow(t bool) {
+	d.autoGrow = t
+}
+
+// Len returns the current number of elements in the deque.
+func (d *IntDeque) Len() int {
+	return d.n
+}
+
+// Cap returns the logical capacity of the deque. Note that this may be
+// smaller than the capacity of the internal slice.
+func (d *IntDeque) Cap() int {
+	return len(d.a)
+}
+
+// index returns the index in the internal slice of the ith element from the
+// front of the deque, where 0 <= i <= len(d.a).
+func (d *IntDeque) index(i int) int {
+	// Elements may begin at rear and continue at front:
+	//
+	//	        h
+	//	 [0 1 _ 3 4 5]
+	//
+	j := d.head + i
+	if j >= len(d.a) {
+		j -= len(d.a)
+	}
+	return j
+}
+
+// reserve makes room for n more elements, growing the internal slice if
+// necessary and auto-growing is enabled, and returns the number of new
+// elements that fit in the deque.
+func (d *IntDeque) reserve(n int) int {
+	if room := len(d.a) - d.n; n > room {
+		if !d.autoGrow {
+			return room
+		}
+		d.Grow(n - room)
+	}
+	return n
+}
+
+// PushFront adds a new element to the front of the deque. If adding this
+// element would overflow the deque and auto-growing is enabled, the current
+// deque is moved to a larger IntDeque before adding the element.
+// Otherwise, the element is dropped.
+func (d *IntDeque) PushFront(x int) {
+	if d.reserve(1) == 0 {
+		return
+	}
+
+	d.head--
+	if d.head < 0 {
+		d.head += len(d.a)
+	}
+
+	d.a[d.head] = x
+	d.n++
+}
+
+// PushBack adds a new element to the back of the deque. If adding this
+// element would overflow the deque and auto-growing is enabled, the current
+// deque is moved to a larger IntDeque before adding the element.
+// Otherwise, the element is dropped.
+func (d *IntDeque) PushBack(x int) {
+	if d.reserve(1) == 0 {
+		return
+	}
+
+	d.a[d.index(d.n)] = x
+	d.n++
+}
+
+// PopFront removes and returns the front element of the deque. Calling
+// PopFront on an empty deque results in a panic.
+func (d *IntDeque) PopFront() int {
+	x := d.Front()
+
+	d.head = d.index(1)
+	d.n--
+
+	return x
+}
+
+// PopBack removes and returns the back element of the deque. Calling PopBack
+// on an empty deque results in a panic.
+func (d *IntDeque) PopBack() int {
+	x := d.Back()
+	d.n--
+
+	return x
+}
+
+// Front returns the front element of the deque without removing it. Peeking
+// an empty deque results in a panic.
+func (d *IntDeque) Front() int {
+	return d.At(0)
+}
+
+// Back returns the back element of the deque without removing it. Peeking an
+// empty deque results in a panic.
+func (d *IntDeque) Back() int {
+	return d.At(d.n - 1)
+}
+
+// At returns the ith element from the front of the deque. Indexing beyond
+// the bounds of the deque results in a panic.
+func (d *IntDeque) At(i int) int {
+	_ = d.a[:d.n][i] // Bounds check; panic() defeats inlining
+	return d.a[d.index(i)]
+}
+
+// Set replaces the ith element from the front of the deque. Indexing beyond
+// the bounds of the deque results in a panic.
+func (d *IntDeque) Set(i int, x int) {
+	_ = d.a[:d.n][i] // Bounds check; panic() defeats inlining
+	d.a[d.index(i)] = x
+}
+
+// PushFrontSlice adds a slice of int to the front of the deque, so
+// that src[0] becomes the front element. If adding these elements would
+// overflow the deque and auto-growing is enabled, the current deque is moved
+// to a larger IntDeque before adding the elements.
+// Otherwise, the elements that do not fit are dropped.
+func (d *IntDeque) PushFrontSlice(src []int) {
+	// The leading elements of src that do not fit are dropped
+	src = src[len(src)-d.reserve(len(src)):]
+	if len(src) == 0 {
+		return
+	}
+
+	d.head -= len(src)
+	if d.head < 0 {
+		d.head += len(d.a)
+	}
+
+	// The free segment ends at the old head, and may begin at rear and
+	// continue at front.
+	n := copy(d.a[d.head:], src)
+	copy(d.a, src[n:])
+
+	d.n += len(src)
+}
+
+// PushBackSlice adds a slice of int to the back of the deque, so that
+// src[len(src)-1] becomes the back element. If adding these elements would
+// overflow the deque and auto-growing is enabled, the current deque is moved
+// to a larger IntDeque before adding the elements.
+// Otherwise, the elements that do not fit are dropped.
+func (d *IntDeque) PushBackSlice(src []int) {
+	// The trailing elements of src that do not fit are dropped
+	src = src[:d.reserve(len(src))]
+	if len(src) == 0 {
+		return
+	}
+
+	// The free segment begins after the back element, and may begin at
+	// rear and continue at front.
+	n := copy(d.a[d.index(d.n):], src)
+	copy(d.a, src[n:])
+
+	d.n += len(src)
+}
+
+// PopFrontSlice removes and writes up to len(dst) elements from the front of
+// the deque into dst, in order from the front. The number of removed elements
+// is returned.
+func (d *IntDeque) PopFrontSlice(dst []int) (n int) {
+	n = len(dst)
+	if d.n < n {
+		n = d.n
+	}
+
+	m := copy(dst[:n], d.a[d.head:])
+	copy(dst[m:n], d.a)
+
+	d.head = d.index(n)
+	d.n -= n
+
+	return n
+}
+
+// PopBackSlice removes and writes up to len(dst) elements from the back of
+// the deque into dst, in order from the back. The number of removed elements
+// is returned.
+func (d *IntDeque) PopBackSlice(dst []int) (n int) {
+	n = len(dst)
+	if d.n < n {
+		n = d.n
+	}
+
+	for i := 0; i < n; i++ {
+		d.n--
+		dst[i] = d.a[d.index(d.n)]
+	}
+
+	return n
+}
+
+// Grow internal slice to accommodate at least n more items.
+func (d *IntDeque) Grow(n int) {
+	// We do not check to see if n <= cap(d.a) - len(d.a) because we promised
+	// never to reslice the current buffer beyond its current length.
+	if n <= 0 {
+		return
+	}
+
+	a := make([]int, 1<<uint(bits.Len(uint(len(d.a)+n-1))))
+
+	m := copy(a[:d.n], d.a[d.head:])
+	copy(a[m:d.n], d.a)
+
+	d.a = a
+	d.head = 0
+}
+
+// Reset the deque so that its length is zero.
+// Note that the internal slice is NOT cleared.
+func (d *IntDeque) Reset() {
+	d.head = 0
+	d.n = 0
+}
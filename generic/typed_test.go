@@ -0,0 +1,350 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/guns/golibs/generic/impl"
+)
+
+// The type-parameterized implementations are tested against the generated
+// implementations in package impl with random sequences of operations.
+
+func TestQueueParity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	buf1, buf2 := make([]int, 8), make([]int, 8)
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(8)
+		q1 := NewQueue[int](size)
+		q2 := impl.NewIntQueue(size)
+
+		if i%2 == 0 {
+			q1 = NewQueueWithBuffer(make([]int, size))
+			q2 = impl.NewIntQueueWithBuffer(make([]int, size))
+		}
+
+		for j := 0; j < 200; j++ {
+			switch op := r.Intn(7); {
+			case op < 3:
+				q1.Enqueue(j)
+				q2.Enqueue(j)
+			case op == 3 && q2.Len() > 0:
+				if x, y := q1.Dequeue(), q2.Dequeue(); x != y {
+					t.Errorf("%v != %v", x, y)
+				}
+			case op == 4:
+				n := r.Intn(len(buf1))
+				for k := range buf1[:n] {
+					buf1[k] = j + k
+				}
+				q1.EnqueueSlice(buf1[:n])
+				q2.EnqueueSlice(buf1[:n])
+			case op == 5:
+				n := r.Intn(len(buf1))
+				n1, n2 := q1.DequeueSlice(buf1[:n]), q2.DequeueSlice(buf2[:n])
+				if n1 != n2 || !reflect.DeepEqual(buf1[:n1], buf2[:n2]) {
+					t.Errorf("%v != %v", buf1[:n1], buf2[:n2])
+				}
+			case op == 6 && r.Intn(10) == 0:
+				q1.Reset()
+				q2.Reset()
+			}
+
+			if q1.Len() != q2.Len() || q1.Cap() != q2.Cap() {
+				t.Errorf("%v != %v", []int{q1.Len(), q1.Cap()}, []int{q2.Len(), q2.Cap()})
+			}
+			if q2.Len() > 0 && q1.Peek() != q2.Peek() {
+				t.Errorf("%v != %v", q1.Peek(), q2.Peek())
+			}
+		}
+	}
+}
+
+func TestStackParity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	buf1, buf2 := make([]int, 8), make([]int, 8)
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(8)
+		s1 := NewStack[int](size)
+		s2 := impl.NewIntStack(size)
+
+		if i%2 == 0 {
+			s1 = NewStackWithBuffer(make([]int, size))
+			s2 = impl.NewIntStackWithBuffer(make([]int, size))
+		}
+
+		for j := 0; j < 200; j++ {
+			switch op := r.Intn(7); {
+			case op < 3:
+				s1.Push(j)
+				s2.Push(j)
+			case op == 3 && s2.Len() > 0:
+				if x, y := s1.Pop(), s2.Pop(); x != y {
+					t.Errorf("%v != %v", x, y)
+				}
+			case op == 4:
+				n := r.Intn(len(buf1))
+				for k := range buf1[:n] {
+					buf1[k] = j + k
+				}
+				s1.PushSlice(buf1[:n])
+				s2.PushSlice(buf1[:n])
+			case op == 5:
+				n := r.Intn(len(buf1))
+				n1, n2 := s1.PopSlice(buf1[:n]), s2.PopSlice(buf2[:n])
+				if n1 != n2 || !reflect.DeepEqual(buf1[:n1], buf2[:n2]) {
+					t.Errorf("%v != %v", buf1[:n1], buf2[:n2])
+				}
+			case op == 6 && r.Intn(10) == 0:
+				s1.Reset()
+				s2.Reset()
+			}
+
+			if s1.Len() != s2.Len() || s1.Cap() != s2.Cap() {
+				t.Errorf("%v != %v", []int{s1.Len(), s1.Cap()}, []int{s2.Len(), s2.Cap()})
+			}
+			if s2.Len() > 0 && s1.Peek() != s2.Peek() {
+				t.Errorf("%v != %v", s1.Peek(), s2.Peek())
+			}
+		}
+	}
+}
+
+func TestDequeParity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	buf1, buf2 := make([]int, 8), make([]int, 8)
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(8)
+		d1 := NewDeque[int](size)
+		d2 := impl.NewIntDeque(size)
+
+		if i%2 == 0 {
+			d1 = NewDequeWithBuffer(make([]int, size))
+			d2 = impl.NewIntDequeWithBuffer(make([]int, size))
+		}
+		if i%3 == 0 {
+			d1.SetAutoGrow(false)
+			d2.SetAutoGrow(false)
+		}
+
+		for j := 0; j < 200; j++ {
+			switch op := r.Intn(9); {
+			case op == 0:
+				d1.PushFront(j)
+				d2.PushFront(j)
+			case op == 1:
+				d1.PushBack(j)
+				d2.PushBack(j)
+			case op == 2 && d2.Len() > 0:
+				if x, y := d1.PopFront(), d2.PopFront(); x != y {
+					t.Errorf("%v != %v", x, y)
+				}
+			case op == 3 && d2.Len() > 0:
+				if x, y := d1.PopBack(), d2.PopBack(); x != y {
+					t.Errorf("%v != %v", x, y)
+				}
+			case op == 4 || op == 5:
+				n := r.Intn(len(buf1))
+				for k := range buf1[:n] {
+					buf1[k] = j + k
+				}
+				if op == 4 {
+					d1.PushFrontSlice(buf1[:n])
+					d2.PushFrontSlice(buf1[:n])
+				} else {
+					d1.PushBackSlice(buf1[:n])
+					d2.PushBackSlice(buf1[:n])
+				}
+			case op == 6 || op == 7:
+				n := r.Intn(len(buf1))
+				var n1, n2 int
+				if op == 6 {
+					n1, n2 = d1.PopFrontSlice(buf1[:n]), d2.PopFrontSlice(buf2[:n])
+				} else {
+					n1, n2 = d1.PopBackSlice(buf1[:n]), d2.PopBackSlice(buf2[:n])
+				}
+				if n1 != n2 || !reflect.DeepEqual(buf1[:n1], buf2[:n2]) {
+					t.Errorf("%v != %v", buf1[:n1], buf2[:n2])
+				}
+			case op == 8 && r.Intn(10) == 0:
+				d1.Reset()
+				d2.Reset()
+			}
+
+			if d1.Len() != d2.Len() || d1.Cap() != d2.Cap() {
+				t.Errorf("%v != %v", []int{d1.Len(), d1.Cap()}, []int{d2.Len(), d2.Cap()})
+			}
+			for k := 0; k < d2.Len(); k++ {
+				if d1.At(k) != d2.At(k) {
+					t.Errorf("%v != %v", d1.At(k), d2.At(k))
+				}
+			}
+		}
+	}
+}
+
+func TestQueueAndStackWithoutAutoGrow(t *testing.T) {
+	buf := make([]string, 2)
+	q := NewQueueWithBuffer(buf)
+	q.SetAutoGrow(false)
+	q.Enqueue("a")
+	q.Enqueue("b")
+
+	if !reflect.DeepEqual(buf, []string{"a", "b"}) {
+		t.Errorf("%v != %v", buf, []string{"a", "b"})
+	}
+	if q.Len() != 2 || q.Dequeue() != "a" {
+		t.Errorf("%v != %v", q.Len(), 2)
+	}
+
+	s := NewStack[string](2)
+	s.SetAutoGrow(false)
+	s.PushSlice([]string{"a", "b"})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected panic on stack overflow")
+			}
+		}()
+		s.Push("c")
+	}()
+}
+
+func TestPacked2DBuilderParity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(8)
+		p1 := NewPacked2DBuilder[int](size)
+		p2 := impl.NewPacked2DIntBuilder(size)
+
+		if i%2 == 0 {
+			rows := [][]int{make([]int, size)}
+			p1 = NewPacked2DBuilderFromRows(rows)
+			p2 = impl.NewPacked2DIntBuilderFromRows(rows)
+		}
+
+		for j := 0; j < 100; j++ {
+			switch op := r.Intn(10); {
+			case op < 7:
+				p1.Append(j)
+				p2.Append(j)
+			case op < 9:
+				p1.FinishRow()
+				p2.FinishRow()
+			case r.Intn(5) == 0:
+				p1.Reset()
+				p2.Reset()
+			}
+
+			if p1.Len() != p2.Len() || p1.Cap() != p2.Cap() {
+				t.Errorf("%v != %v", []int{p1.Len(), p1.Cap()}, []int{p2.Len(), p2.Cap()})
+			}
+			if !reflect.DeepEqual(p1.Rows, p2.Rows) {
+				t.Errorf("%v != %v", p1.Rows, p2.Rows)
+			}
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		ints := make([]int, 10*i)
+		for j := range ints {
+			ints[j] = r.Intn(5*i + 1)
+		}
+
+		s1 := append([]int(nil), ints...)
+		s2 := append([]int(nil), ints...)
+		sort.Ints(s1)
+		Sort(s2)
+
+		if !reflect.DeepEqual(s1, s2) {
+			t.Errorf("%v != %v", s1, s2)
+		}
+
+		strs := make([]string, i)
+		for j := range strs {
+			strs[j] = string(rune('a' + r.Intn(26)))
+		}
+
+		t1 := append([]string(nil), strs...)
+		t2 := append([]string(nil), strs...)
+		sort.Strings(t1)
+		Sort(t2)
+
+		if !reflect.DeepEqual(t1, t2) {
+			t.Errorf("%v != %v", t1, t2)
+		}
+	}
+}
+
+func TestSortNaN(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		v := make([]float64, i)
+		for j := range v {
+			if r.Intn(2) == 0 || i%10 == 0 {
+				v[j] = math.NaN()
+			} else {
+				v[j] = float64(r.Intn(10))
+			}
+		}
+
+		expected := append([]float64(nil), v...)
+		sort.Float64s(expected)
+		Sort(v)
+
+		for j := range v {
+			if v[j] != expected[j] && !(math.IsNaN(v[j]) && math.IsNaN(expected[j])) {
+				t.Errorf("%v != %v", v, expected)
+				break
+			}
+		}
+	}
+}
+
+func TestTypedMinMax(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		v := make([]int, 5)
+		for j := range v {
+			v[j] = r.Intn(21) - 10
+		}
+
+		min := [5]int{Min(v[0], v[1]), Min3(v[0], v[1], v[2]), MinV[int](), MinV(v...), MinSlice(v)}
+		max := [5]int{Max(v[0], v[1]), Max3(v[0], v[1], v[2]), MaxV[int](), MaxV(v...), MaxSlice(v)}
+
+		implMin := [5]int{impl.MinInt2(v[0], v[1]), impl.MinInt3(v[0], v[1], v[2]), impl.MinIntV(), impl.MinIntV(v...), impl.MinIntSlice(v)}
+		implMax := [5]int{impl.MaxInt2(v[0], v[1]), impl.MaxInt3(v[0], v[1], v[2]), impl.MaxIntV(), impl.MaxIntV(v...), impl.MaxIntSlice(v)}
+
+		if min != implMin {
+			t.Errorf("%v != %v", min, implMin)
+		}
+		if max != implMax {
+			t.Errorf("%v != %v", max, implMax)
+		}
+		if Abs(v[0]) != impl.AbsInt(v[0]) {
+			t.Errorf("%v != %v", Abs(v[0]), impl.AbsInt(v[0]))
+		}
+	}
+
+	if Min(1.5, -2.5) != -2.5 || Max("a", "b") != "b" || Abs(float32(-1)) != 1 {
+		t.Errorf("%v %v %v", Min(1.5, -2.5), Max("a", "b"), Abs(float32(-1)))
+	}
+}
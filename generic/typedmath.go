@@ -0,0 +1,119 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import "cmp"
+
+// Number is a constraint that permits any signed integer or floating-point
+// type; i.e. any numeric type with a negation. Unlike constraints.Signed, it
+// includes floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// The following functions are the type-parameterized equivalents of the
+// functions in math.go.
+
+// Abs returns the absolute value of a.
+func Abs[T Number](a T) T {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Min returns the minimum of a and b.
+func Min[T cmp.Ordered](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Min3 returns the minimum of a, b, and c.
+func Min3[T cmp.Ordered](a, b, c T) T {
+	if a < b {
+		if a < c {
+			return a
+		}
+		return c
+	}
+	if b < c {
+		return b
+	}
+	return c
+}
+
+// MinV returns the minimum of all parameters.
+func MinV[T cmp.Ordered](nums ...T) T {
+	return MinSlice(nums)
+}
+
+// MinSlice returns the minimum of a slice of T, or the zero value of T if
+// the slice is empty.
+func MinSlice[T cmp.Ordered](nums []T) T {
+	var min T
+
+	if len(nums) == 0 {
+		return min
+	}
+
+	min = nums[0]
+
+	for _, n := range nums[1:] {
+		if n < min {
+			min = n
+		}
+	}
+
+	return min
+}
+
+// Max returns the maximum of a and b.
+func Max[T cmp.Ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Max3 returns the maximum of a, b, and c.
+func Max3[T cmp.Ordered](a, b, c T) T {
+	if a > b {
+		if a > c {
+			return a
+		}
+		return c
+	}
+	if b > c {
+		return b
+	}
+	return c
+}
+
+// MaxV returns the maximum of all parameters.
+func MaxV[T cmp.Ordered](nums ...T) T {
+	return MaxSlice(nums)
+}
+
+// MaxSlice returns the maximum of a slice of T, or the zero value of T if
+// the slice is empty.
+func MaxSlice[T cmp.Ordered](nums []T) T {
+	var max T
+
+	if len(nums) == 0 {
+		return max
+	}
+
+	max = nums[0]
+
+	for _, n := range nums[1:] {
+		if n > max {
+			max = n
+		}
+	}
+
+	return max
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import "math/bits"
+
+// Queue is an optionally auto-growing queue backed by a ring buffer. It is the
+// type-parameterized equivalent of GenericTypeQueue.
+type Queue[T any] struct {
+	a          []T
+	head, tail int
+	autoGrow   bool
+}
+
+// NewQueue returns a new auto-growing queue that can accommodate at least size
+// items.
+func NewQueue[T any](size int) *Queue[T] {
+	return NewQueueWithBuffer(
+		make([]T, 1<<uint(bits.Len(uint(size-1)))),
+	)
+}
+
+// NewQueueWithBuffer returns a new auto-growing queue that wraps the provided
+// buffer, which is never resliced beyond its current length.
+func NewQueueWithBuffer[T any](buf []T) *Queue[T] {
+	return &Queue[T]{
+		a:        buf,
+		head:     -1,
+		tail:     -1,
+		autoGrow: true,
+	}
+}
+
+// SetAutoGrow enables or disables auto-growing.
+func (q *Queue[T]) SetAutoGrow(t bool) {
+	q.autoGrow = t
+}
+
+// Len returns the current number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	switch {
+	case q.head == -1:
+		// Queue is empty:
+		//
+		//	h
+		//	 [_ _ _ _ _ _]
+		//	t
+		//
+		return 0
+	case q.head < q.tail:
+		// Elements are in order:
+		//
+		//	    h
+		//	 [_ 1 2 3 _ _]
+		//	          t
+		//
+		return q.tail - q.head
+	default:
+		// Elements begin at rear and continue at front:
+		//
+		//	        h
+		//	 [0 1 _ 3 4 5]
+		//	      t
+		//
+		return len(q.a) - q.head + q.tail
+	}
+}
+
+// Cap returns the logical capacity of the queue. Note that this may be smaller
+// than the capacity of the internal slice.
+func (q *Queue[T]) Cap() int {
+	return len(q.a)
+}
+
+// Enqueue a new element into the queue. If adding this element would overflow
+// the queue and auto-growing is enabled, the current queue is moved to a
+// larger Queue before adding the element.
+func (q *Queue[T]) Enqueue(x T) {
+	if q.tail == -1 {
+		q.head = 0
+		q.tail = 0
+		if len(q.a) == 0 {
+			q.Grow(1)
+		}
+	} else if q.autoGrow && q.head == q.tail {
+		q.Grow(1)
+	}
+
+	q.a[q.tail] = x
+
+	q.tail++
+	if q.tail >= len(q.a) {
+		q.tail -= len(q.a)
+	}
+}
+
+// Dequeue removes and returns the next element from the queue. Calling Dequeue
+// on an empty queue results in a panic.
+func (q *Queue[T]) Dequeue() T {
+	x := q.a[q.head]
+
+	q.head++
+	if q.head >= len(q.a) {
+		q.head -= len(q.a)
+	}
+
+	if q.head == q.tail {
+		q.Reset()
+	}
+
+	return x
+}
+
+// EnqueueSlice adds a slice of T into the queue. If adding these elements
+// would overflow the queue and auto-growing is enabled, the current queue is
+// moved to a larger Queue before adding the elements.
+func (q *Queue[T]) EnqueueSlice(src []T) {
+	if len(src) == 0 {
+		return
+	}
+
+	if q.autoGrow {
+		newlen := q.Len() + len(src)
+		if newlen > len(q.a) {
+			q.Grow(newlen - len(q.a))
+		}
+	}
+
+	switch {
+	case q.head == -1:
+		// Queue is empty:
+		//
+		//	h
+		//	 [_ _ _ _ _ _]
+		//	t
+		//
+		q.head = 0
+		q.tail = 0
+
+		q.tail += copy(q.a, src)
+		if q.tail >= len(q.a) {
+			q.tail -= len(q.a)
+		}
+	case q.tail < q.head:
+		// Free segment is contiguous:
+		//
+		//	          h
+		//	 [0 _ _ _ 4 5]
+		//	    t
+		//
+		q.tail += copy(q.a[q.tail:], src)
+	default:
+		// Free segment begins at rear and continues at front:
+		//
+		//	      h
+		//	 [_ _ 2 3 _ _]
+		//	          t
+		//
+		n := copy(q.a[q.tail:], src)
+		if n < len(src) {
+			n += copy(q.a, src[n:])
+		}
+
+		q.tail += n
+		if q.tail >= len(q.a) {
+			q.tail -= len(q.a)
+		}
+	}
+}
+
+// DequeueSlice removes and writes up to len(dst) elements from the queue into
+// dst. The number of dequeued elements is returned.
+func (q *Queue[T]) DequeueSlice(dst []T) (n int) {
+	switch {
+	case q.head == -1:
+		// Queue is empty:
+		//
+		//	h
+		//	 [_ _ _ _ _ _]
+		//	t
+		return 0
+	case q.head < q.tail:
+		// Elements are in order:
+		//
+		//	    h
+		//	 [_ 1 2 3 _ _]
+		//	          t
+		//
+		n := copy(dst, q.a[q.head:q.tail])
+		q.head += n
+
+		if q.head == q.tail {
+			q.Reset()
+		}
+
+		return n
+	default:
+		// Elements begin at rear and continue at front:
+		//
+		//	        h
+		//	 [0 1 _ 3 4 5]
+		//	      t
+		//
+		n := copy(dst, q.a[q.head:])
+		if n < len(dst) {
+			n += copy(dst[n:], q.a[:q.tail])
+		}
+
+		q.head += n
+		if q.head >= len(q.a) {
+			q.head -= len(q.a)
+		}
+
+		if q.head == q.tail {
+			q.Reset()
+		}
+
+		return n
+	}
+}
+
+// Peek returns the next element from the queue without removing it. Peeking an
+// empty queue results in a panic.
+func (q *Queue[T]) Peek() T {
+	return q.a[q.head]
+}
+
+// Grow internal slice to accommodate at least n more items.
+func (q *Queue[T]) Grow(n int) {
+	// We do not check to see if n <= cap(q.a) - len(q.a) because we promised
+	// never to reslice the current buffer beyond its current length.
+	if n <= 0 {
+		return
+	}
+
+	a := make([]T, 1<<uint(bits.Len(uint(len(q.a)+n-1))))
+
+	switch {
+	case q.head == -1:
+		// Queue is empty:
+		//
+		//	h
+		//	 [_ _ _ _ _ _]
+		//	t
+		q.a = a
+	case q.head < q.tail:
+		// Elements are in order:
+		//
+		//	    h
+		//	 [_ 1 2 3 _ _]
+		//	          t
+		//
+		q.tail = copy(a, q.a[q.head:q.tail])
+		q.head = 0
+		q.a = a
+	default:
+		// Elements begin at rear and continue at front:
+		//
+		//	        h
+		//	 [0 1 _ 3 4 5]
+		//	      t
+		//
+		n := copy(a, q.a[q.head:])
+		n += copy(a[n:], q.a[:q.tail])
+		q.tail = n
+		q.head = 0
+		q.a = a
+	}
+}
+
+// Reset the queue so that its length is zero. Note that the internal slice is
+// NOT cleared.
+func (q *Queue[T]) Reset() {
+	q.head = -1
+	q.tail = -1
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import "cmp"
+
+// Sort sorts a slice of T in place. It is the type-parameterized equivalent
+// of QuicksortGenericNumberSlice, and is not stable. Elements are ordered by
+// cmp.Less, so floating-point NaNs are ordered before all other values.
+func Sort[T cmp.Ordered](v []T) {
+	switch len(v) {
+	case 0, 1:
+		return
+	// Manually sort small slices
+	case 2:
+		if cmp.Less(v[1], v[0]) {
+			v[0], v[1] = v[1], v[0]
+		}
+		return
+	case 3:
+		if cmp.Less(v[1], v[0]) {
+			v[0], v[1] = v[1], v[0]
+		}
+		if cmp.Less(v[2], v[1]) {
+			v[1], v[2] = v[2], v[1]
+		}
+		if cmp.Less(v[1], v[0]) {
+			v[0], v[1] = v[1], v[0]
+		}
+		return
+	}
+
+	i := partition(v)
+	Sort(v[:i+1])
+	Sort(v[i+1:])
+}
+
+// partition partitions a slice of T in place such that every element
+// 0..index is less than or equal to every element index+1..len(v-1).
+func partition[T cmp.Ordered](v []T) (index int) {
+	// Hoare's partitioning with median of first, middle, and last as pivot
+	var pivot T
+
+	if len(v) > 16 {
+		pivot = medianOfThreeSamples(v)
+	} else {
+		pivot = v[(len(v)-1)/2]
+	}
+
+	i, j := -1, len(v)
+
+	for {
+		for {
+			i++
+			if !cmp.Less(v[i], pivot) {
+				break
+			}
+		}
+
+		for {
+			j--
+			if !cmp.Less(pivot, v[j]) {
+				break
+			}
+		}
+
+		if i < j {
+			v[i], v[j] = v[j], v[i]
+		} else {
+			return j
+		}
+	}
+}
+
+// medianOfThreeSamples returns the median of the first, middle, and last
+// element.
+func medianOfThreeSamples[T cmp.Ordered](v []T) T {
+	a := v[0]
+	b := v[(len(v)-1)/2]
+	c := v[len(v)-1]
+
+	if cmp.Less(b, a) {
+		a, b = b, a
+	}
+	if cmp.Less(c, b) {
+		b = c
+		if cmp.Less(b, a) {
+			b = a
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+// GenericNumberUnionFind is a disjoint-set forest over the integers [0, n),
+// with path compression (by halving) and union by size. Elements are stored
+// as GenericNumber, so narrow integer types can be used to reduce memory
+// usage.
+type GenericNumberUnionFind struct {
+	parent []GenericNumber // Element -> parent element
+	size   []GenericNumber // Root element -> number of elements in its set
+	count  int             // Number of disjoint sets
+}
+
+// NewGenericNumberUnionFind returns a new union-find of size elements, each
+// of which is in its own set.
+func NewGenericNumberUnionFind(size int) *GenericNumberUnionFind {
+	return NewGenericNumberUnionFindWithBuffer(make([]GenericNumber, size*2))
+}
+
+// NewGenericNumberUnionFindWithBuffer returns a new union-find that wraps the
+// provided buffer, which is never resliced beyond its current length. The
+// union-find has len(buf)/2 elements, each of which is in its own set.
+func NewGenericNumberUnionFindWithBuffer(buf []GenericNumber) *GenericNumberUnionFind {
+	n := len(buf) / 2
+
+	u := &GenericNumberUnionFind{
+		parent: buf[:n],
+		size:   buf[n : n*2],
+	}
+
+	u.Reset()
+
+	return u
+}
+
+// Len returns the number of elements.
+func (u *GenericNumberUnionFind) Len() int {
+	return len(u.parent)
+}
+
+// Count returns the current number of disjoint sets.
+func (u *GenericNumberUnionFind) Count() int {
+	return u.count
+}
+
+// Find returns the representative element of the set that contains x.
+func (u *GenericNumberUnionFind) Find(x int) int {
+	// Path halving
+	for {
+		p := int(u.parent[x])
+		if p == x {
+			return x
+		}
+
+		gp := u.parent[p]
+		u.parent[x] = gp
+		x = int(gp)
+	}
+}
+
+// Union merges the sets that contain x and y. Returns true if x and y were
+// in different sets, and false if not.
+func (u *GenericNumberUnionFind) Union(x, y int) bool {
+	x, y = u.Find(x), u.Find(y)
+
+	if x == y {
+		return false
+	}
+
+	// Union by size
+	if u.size[x] < u.size[y] {
+		x, y = y, x
+	}
+
+	u.parent[y] = GenericNumber(x)
+	u.size[x] += u.size[y]
+	u.count--
+
+	return true
+}
+
+// Connected returns true if x and y are in the same set.
+func (u *GenericNumberUnionFind) Connected(x, y int) bool {
+	return u.Find(x) == u.Find(y)
+}
+
+// Size returns the number of elements in the set that contains x.
+func (u *GenericNumberUnionFind) Size(x int) int {
+	return int(u.size[u.Find(x)])
+}
+
+// Reset the union-find so that each element is in its own set.
+func (u *GenericNumberUnionFind) Reset() {
+	for i := range u.parent {
+		u.parent[i] = GenericNumber(i)
+		u.size[i] = 1
+	}
+
+	u.count = len(u.parent)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package generic
+
+import "testing"
+
+func TestUnionFind(t *testing.T) {
+	type UnionFind = GenericNumberUnionFind
+
+	data := []struct {
+		size   int
+		unions [][2]int
+		merged []bool
+		count  int
+		sizes  []int
+	}{
+		{size: 0, unions: [][2]int{}, merged: []bool{}, count: 0, sizes: []int{}},
+		{size: 1, unions: [][2]int{{0, 0}}, merged: []bool{false}, count: 1, sizes: []int{1}},
+		{
+			size:   5,
+			unions: [][2]int{{0, 1}, {2, 3}, {1, 0}, {3, 1}},
+			merged: []bool{true, true, false, true},
+			count:  2,
+			sizes:  []int{4, 4, 4, 4, 1},
+		},
+		{
+			size:   6,
+			unions: [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 0}},
+			merged: []bool{true, true, true, true, true, false},
+			count:  1,
+			sizes:  []int{6, 6, 6, 6, 6, 6},
+		},
+	}
+
+	for _, row := range data {
+		for _, u := range []*UnionFind{
+			NewGenericNumberUnionFind(row.size),
+			NewGenericNumberUnionFindWithBuffer(make([]GenericNumber, row.size*2+1)),
+		} {
+			if u.Len() != row.size {
+				t.Errorf("%v != %v", u.Len(), row.size)
+			}
+
+			for x := 0; x < row.size; x++ {
+				if u.Find(x) != x {
+					t.Errorf("%v != %v", u.Find(x), x)
+				}
+			}
+
+			for i, p := range row.unions {
+				if u.Union(p[0], p[1]) != row.merged[i] {
+					t.Errorf("%v != %v", !row.merged[i], row.merged[i])
+				}
+				if !u.Connected(p[0], p[1]) {
+					t.Errorf("%v and %v are not connected", p[0], p[1])
+				}
+			}
+
+			if u.Count() != row.count {
+				t.Errorf("%v != %v", u.Count(), row.count)
+			}
+
+			for x, size := range row.sizes {
+				if u.Size(x) != size {
+					t.Errorf("%v != %v", u.Size(x), size)
+				}
+				for y, ysize := range row.sizes {
+					if u.Connected(x, y) != (u.Find(x) == u.Find(y)) || (u.Connected(x, y) && ysize != size) {
+						t.Errorf("Connected(%v, %v) is inconsistent", x, y)
+					}
+				}
+			}
+
+			u.Reset()
+
+			if u.Count() != row.size {
+				t.Errorf("%v != %v", u.Count(), row.size)
+			}
+			for x := 0; x < row.size; x++ {
+				if u.Find(x) != x || u.Size(x) != 1 {
+					t.Errorf("%v was not reset", x)
+				}
+			}
+		}
+	}
+}
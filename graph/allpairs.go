@@ -0,0 +1,175 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+// AllPairsShortestPaths returns a |V|×|V| matrix of minimum total edge
+// weights, where dist[u][v] is the weight of a shortest path from vertex u to
+// v, or +Inf if no path exists. Edge weights are read from weights, which must
+// be parallel to g, and may be negative.
+//
+// As with LeastEdgesPath, trivial paths are not considered, so dist[u][u] is
+// the weight of a shortest cycle through u. A negative value on the diagonal
+// therefore indicates that u lies on a negative cycle, in which case the
+// other distances are meaningless.
+//
+// The matrix is written to dist, which is grown if necessary. Note that the
+// returned [][]float64 is backed by a single []float64 (dist[0][:cap(dist[0])])
+// to minimize allocations. Passing the same matrix returned by this function
+// as the dist parameter reuses memory.
+func (g Graph) AllPairsShortestPaths(dist [][]float64, weights [][]float64) [][]float64 {
+	// Floyd-Warshall
+	dist = makeFloatMatrix(dist, len(g))
+
+	inf := math.Inf(1)
+	for u := range dist {
+		row := dist[u]
+		for v := range row {
+			row[v] = inf
+		}
+		for i, v := range g[u] {
+			if weights[u][i] < row[v] {
+				row[v] = weights[u][i]
+			}
+		}
+	}
+
+	for k := range dist {
+		rowk := dist[k]
+
+		for u := range dist {
+			rowu := dist[u]
+			duk := rowu[k]
+
+			if duk == inf {
+				continue
+			}
+
+			for v, dkv := range rowk {
+				if alt := duk + dkv; alt < rowu[v] {
+					rowu[v] = alt
+				}
+			}
+		}
+	}
+
+	return dist
+}
+
+// TransitiveClosure returns the reachability matrix of the graph as a slice
+// of bitslices: tc[u].Get(v) is true iff there is a path from vertex u to v.
+//
+// As with LeastEdgesPath, trivial paths are not considered, so tc[u].Get(u)
+// is true iff u lies on a cycle or has a self-edge.
+//
+// The matrix is written to tc, which is grown if necessary. Note that the
+// returned []bitslice.T is backed by a single slice (tc[0][:cap(tc[0])]) to
+// minimize allocations. Passing the same slice returned by this function as
+// the tc parameter reuses memory.
+//
+// The strongly connected components of the graph are computed as a side
+// effect, and are written to scc and returned as with
+// StronglyConnectedComponents. Passing the returned components as the scc
+// parameter reuses memory.
+func (g Graph) TransitiveClosure(tc []bitslice.T, scc [][]int, w *Workspace) ([]bitslice.T, [][]int) {
+	tc = makeBitMatrix(tc, len(g))
+
+	// Components are returned in reverse topological order, so the closure of
+	// every successor component is complete before it is needed.
+	scc = g.StronglyConnectedComponents(scc, w)
+
+	comp := w.a // |V|w · Slice of vertex -> component index
+	for i := range scc {
+		for _, v := range scc[i] {
+			comp[v] = i
+		}
+	}
+
+	for i := range scc {
+		reach := tc[scc[i][0]]
+
+		for _, u := range scc[i] {
+			for _, v := range g[u] {
+				reach.Set(v)
+				if comp[v] != i {
+					reach.Or(tc[v])
+				}
+			}
+		}
+
+		for _, u := range scc[i][1:] {
+			copy(tc[u], reach)
+		}
+	}
+
+	return tc, scc
+}
+
+// makeFloatMatrix returns a size×size matrix backed by a single slice,
+// reusing the memory of m if possible. Note that the matrix is NOT cleared.
+func makeFloatMatrix(m [][]float64, size int) [][]float64 {
+	var buf []float64
+
+	if cap(m) >= size && size > 0 {
+		buf = m[:1][0]
+		buf = buf[:cap(buf)]
+	}
+
+	if len(buf) < size*size {
+		buf = make([]float64, size*size)
+	}
+
+	if cap(m) >= size {
+		m = m[:size]
+	} else {
+		m = make([][]float64, size)
+	}
+
+	for i := range m {
+		m[i] = buf[i*size : (i+1)*size]
+	}
+
+	return m
+}
+
+// makeBitMatrix returns a zeroed matrix of size bitslices with a capacity of
+// size bits each, backed by a single slice and reusing the memory of m if
+// possible.
+func makeBitMatrix(m []bitslice.T, size int) []bitslice.T {
+	var buf []uint
+
+	n := bitslice.UintLen(size)
+
+	if cap(m) >= size && size > 0 {
+		buf = m[:1][0]
+		buf = buf[:cap(buf)]
+	}
+
+	if len(buf) < size*n {
+		buf = make([]uint, size*n)
+	} else {
+		buf = buf[:size*n]
+		for i := range buf {
+			buf[i] = 0
+		}
+	}
+
+	if cap(m) >= size {
+		m = m[:size]
+	} else {
+		m = make([]bitslice.T, size)
+	}
+
+	for i := range m {
+		m[i] = bitslice.T(buf[i*n : (i+1)*n])
+	}
+
+	return m
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+func TestGraphAllPairsShortestPaths(t *testing.T) {
+	type E = weightedEdge
+
+	inf := math.Inf(1)
+
+	data := []struct {
+		size  int
+		edges []E
+		dist  [][]float64
+	}{
+		{
+			size:  0,
+			edges: nil,
+			dist:  nil,
+		},
+		// CLRS Figure 25.1
+		{
+			size: 5,
+			edges: []E{
+				{0, 1, 3}, {0, 2, 8}, {0, 4, -4},
+				{1, 3, 1}, {1, 4, 7},
+				{2, 1, 4},
+				{3, 0, 2}, {3, 2, -5},
+				{4, 3, 6},
+			},
+			dist: [][]float64{
+				{4, 1, -3, 2, -4},
+				{3, 0, -4, 1, -1},
+				{7, 4, 0, 5, 3},
+				{2, -1, -5, 0, -2},
+				{8, 5, 1, 6, 4},
+			},
+		},
+		// Disconnected, with a self-loop and parallel edges
+		{
+			size:  3,
+			edges: []E{{0, 1, 5}, {0, 1, 2}, {2, 2, 1}},
+			dist: [][]float64{
+				{inf, 2, inf},
+				{inf, inf, inf},
+				{inf, inf, 1},
+			},
+		},
+	}
+
+	var dist [][]float64
+
+	for _, row := range data {
+		g, weights := makeWeightedGraph(row.size, row.edges)
+
+		dist = g.AllPairsShortestPaths(dist, weights)
+
+		if !reflect.DeepEqual(dist, row.dist) {
+			t.Errorf("%v != %v", dist, row.dist)
+		}
+	}
+}
+
+func TestGraphTransitiveClosure(t *testing.T) {
+	data := []struct {
+		size int
+		adj  map[int][]int
+	}{
+		{
+			size: 0,
+			adj:  map[int][]int{},
+		},
+		{
+			size: 4,
+			adj: map[int][]int{
+				0: {1},
+				1: {2},
+				2: {},
+				3: {3},
+			},
+		},
+		{
+			size: 8,
+			adj: map[int][]int{
+				0: {4},
+				1: {0},
+				2: {1, 3},
+				3: {2},
+				4: {1},
+				5: {1, 4, 6},
+				6: {2, 5},
+				7: {3, 6, 7},
+			},
+		},
+		{
+			size: 70,
+			adj: map[int][]int{
+				0:  {69},
+				69: {1, 68},
+				68: {0},
+				1:  {2},
+				2:  {3},
+			},
+		},
+	}
+
+	w := NewWorkspace(0)
+	var tc []bitslice.T
+	var scc [][]int
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+
+		for u, edges := range row.adj {
+			for _, v := range edges {
+				g.AddEdge(u, v)
+			}
+		}
+
+		tc, scc = g.TransitiveClosure(tc, scc, w)
+
+		if len(tc) != len(g) {
+			t.Errorf("%v != %v", len(tc), len(g))
+		}
+		if s := g.StronglyConnectedComponents(nil, w); !reflect.DeepEqual(scc, s) {
+			t.Errorf("%v != %v", scc, s)
+		}
+
+		// Compare against LeastEdgesPath
+		var path []int
+		for u := range g {
+			for v := range g {
+				path = g.LeastEdgesPath(path, u, v, w)
+				if tc[u].Get(v) != (len(path) > 0) {
+					t.Errorf("tc[%v].Get(%v) != %v", u, v, len(path) > 0)
+				}
+			}
+		}
+	}
+}
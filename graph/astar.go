@@ -0,0 +1,66 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import "math"
+
+// AStarPath is like ShortestPath, but directs the search towards v with a
+// heuristic function that estimates the total weight of a shortest path from
+// a vertex to v.
+//
+// The heuristic must be admissible; i.e. it must never overestimate, or the
+// returned path may not be a shortest path. If the heuristic is also
+// consistent (h(x) <= weight(x, y) + h(y) for every edge (x, y)), every vertex
+// is expanded at most once. A heuristic that always returns zero is
+// equivalent to ShortestPath.
+//
+// All edge weights must be non-negative. The path is written to the path
+// slice, which is grown if necessary. If no path exists, an empty slice and
+// +Inf are returned.
+func (g Graph) AStarPath(path []int, u, v int, weights [][]float64, heuristic func(v int) float64, w *Workspace) ([]int, float64) {
+	w.prepare(len(g), wANeg|wFInf|wEInf)
+
+	pred := w.a                 // |V|w  · Slice of vertex -> predecessor vertex
+	dist := w.e                 // |V|f  · Slice of vertex -> weighted distance from u
+	est := w.f                  // |V|f  · Slice of vertex -> dist + heuristic
+	heap := w.makeHeap(wB | wC) // 2|V|w · Priority queue of vertices keyed by est
+
+	// If u == v, u is the endpoint, so leave its distance undefined.
+	target := v
+	if u != target {
+		dist[u] = 0
+	}
+
+	x, d := u, 0.0
+
+	for {
+		for i, y := range g[x] {
+			if alt := d + weights[x][i]; alt < dist[y] {
+				dist[y] = alt
+				est[y] = alt + heuristic(y)
+				pred[y] = x
+				heap.update(y) // Reopens y if it was already expanded
+			}
+		}
+
+		if heap.len == 0 {
+			break
+		}
+
+		x = heap.pop()
+		d = dist[x]
+
+		if x == target {
+			break
+		}
+	}
+
+	if pred[target] == undefined {
+		// No path from u -> v was discovered
+		return path[:0], math.Inf(1)
+	}
+
+	return writePath(path, pred, target, predPathLen(pred, u, target)), dist[target]
+}
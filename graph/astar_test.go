@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// makeGrid returns a symmetric w×h grid graph with 4-neighbor edges. Cells in
+// blocked have no edges. Edge weights are the weight of the head cell.
+func makeGrid(w, h int, blocked map[int]bool, cost func(v int) float64) (Graph, [][]float64) {
+	g := make(Graph, w*h)
+	weights := make([][]float64, w*h)
+
+	for u := range g {
+		if blocked[u] {
+			continue
+		}
+
+		x, y := u%w, u/w
+
+		for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx < 0 || nx >= w || ny < 0 || ny >= h || blocked[ny*w+nx] {
+				continue
+			}
+			v := ny*w + nx
+			g.AddEdge(u, v)
+			weights[u] = append(weights[u], cost(v))
+		}
+	}
+
+	return g, weights
+}
+
+func TestGraphAStarPath(t *testing.T) {
+	const width = 5
+
+	manhattan := func(target int) func(v int) float64 {
+		return func(v int) float64 {
+			dx, dy := v%width-target%width, v/width-target/width
+			return math.Abs(float64(dx)) + math.Abs(float64(dy))
+		}
+	}
+
+	// 0  1  2  3  4
+	// 5  #  #  #  9
+	// 10 11 12 #  14
+	blocked := map[int]bool{6: true, 7: true, 8: true, 13: true}
+	g, weights := makeGrid(width, 3, blocked, func(int) float64 { return 1 })
+
+	data := []struct {
+		u, v int
+		path []int
+		dist float64
+	}{
+		{u: 10, v: 14, path: []int{10, 5, 0, 1, 2, 3, 4, 9, 14}, dist: 8},
+		{u: 12, v: 12, path: []int{12, 11, 12}, dist: 2},
+		{u: 0, v: 6, path: []int{}, dist: math.Inf(1)},
+		{u: 0, v: 1, path: []int{0, 1}, dist: 1},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		path, dist := g.AStarPath([]int{}, row.u, row.v, weights, manhattan(row.v), w)
+
+		if !reflect.DeepEqual(path, row.path) {
+			t.Errorf("%v != %v", path, row.path)
+		}
+		if dist != row.dist {
+			t.Errorf("%v != %v", dist, row.dist)
+		}
+	}
+}
+
+func TestGraphAStarPathRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 100; trial++ {
+		width, height := r.Intn(10)+1, r.Intn(10)+1
+		blocked := map[int]bool{}
+		for i := r.Intn(width*height/3 + 1); i > 0; i-- {
+			blocked[r.Intn(width*height)] = true
+		}
+		costs := make([]float64, width*height)
+		for i := range costs {
+			costs[i] = float64(r.Intn(5) + 1)
+		}
+
+		g, weights := makeGrid(width, height, blocked, func(v int) float64 { return costs[v] })
+
+		u, v := r.Intn(len(g)), r.Intn(len(g))
+		manhattan := func(x int) float64 {
+			dx, dy := x%width-v%width, x/width-v/width
+			return math.Abs(float64(dx)) + math.Abs(float64(dy))
+		}
+		zero := func(int) float64 { return 0 }
+
+		_, want := g.ShortestPath(nil, u, v, weights, w)
+
+		for _, h := range []func(int) float64{manhattan, zero} {
+			path, dist := g.AStarPath(nil, u, v, weights, h, w)
+
+			if dist != want {
+				t.Errorf("%v != %v", dist, want)
+			}
+
+			sum := 0.0
+			for i := 0; i+1 < len(path); i++ {
+				sum += edgeWeight(g, weights, path[i], path[i+1])
+			}
+			if len(path) > 0 && sum != dist {
+				t.Errorf("%v != %v", sum, dist)
+			}
+		}
+	}
+}
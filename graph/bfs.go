@@ -0,0 +1,178 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// MultiSourceBFS computes the edge distance from the nearest of a set of
+// source vertices to every vertex of the graph.
+//
+// The distance of each vertex is written to dist, and the nearest source is
+// written to nearest. Both slices are grown if necessary. Unreachable
+// vertices have a distance and nearest source of -1. If a vertex is equally
+// near to multiple sources, the source that appears first in sources is
+// chosen, so the results are deterministic.
+func (g Graph) MultiSourceBFS(dist, nearest, sources []int, w *Workspace) ([]int, []int) {
+	return MultiSourceBFS(g, dist, nearest, sources, w)
+}
+
+// MultiSourceBFS is like (Graph).MultiSourceBFS, but accepts any Adjacency.
+func MultiSourceBFS[A Adjacency](g A, dist, nearest, sources []int, w *Workspace) ([]int, []int) {
+	n := g.Len()
+	w.prepare(n, wBNeg)
+
+	queue := w.makeQueue(wA) // |V|w · BFS queue
+	src := w.b               // |V|w · Slice of vertex -> index of nearest source
+
+	dist = resizeIntSlice(dist, n)
+	for i := range dist {
+		dist[i] = undefined
+	}
+
+	for i, s := range sources {
+		if dist[s] == undefined {
+			dist[s] = 0
+			src[s] = i
+			queue.Enqueue(s)
+		}
+	}
+
+	for queue.Len() > 0 {
+		u := queue.Dequeue()
+
+		for _, v := range g.Neighbors(u) {
+			if dist[v] == undefined {
+				dist[v] = dist[u] + 1
+				src[v] = src[u]
+				queue.Enqueue(v)
+			} else if dist[v] == dist[u]+1 && src[u] < src[v] {
+				// All vertices at the distance of u are dequeued before
+				// v, so src[v] is final when v is dequeued.
+				src[v] = src[u]
+			}
+		}
+	}
+
+	nearest = resizeIntSlice(nearest, n)
+	for v := range nearest {
+		nearest[v] = undefined
+		if dist[v] != undefined {
+			nearest[v] = sources[src[v]]
+		}
+	}
+
+	return dist, nearest
+}
+
+// ParallelMultiSourceBFS is like MultiSourceBFS, but explores each level of
+// the search in parallel. Every level is split across one goroutine per
+// Workspace in ws, and each goroutine collects the next level in its own
+// Workspace, so ws must not be empty. The results are identical to those of
+// MultiSourceBFS.
+//
+// The Neighbors method of g must be safe for concurrent use. This is true of
+// Graph and CSR.
+//
+// Note that this function allocates an []int64 of |V| elements for the
+// shared search state.
+func ParallelMultiSourceBFS[A Adjacency](g A, dist, nearest, sources []int, ws []*Workspace) ([]int, []int) {
+	n := g.Len()
+
+	for _, w := range ws {
+		w.prepare(n, 0)
+	}
+
+	// The state of each vertex is its distance and the index of its nearest
+	// source packed into a single word, so that a vertex can be claimed,
+	// and its nearest source lowered, with a single atomic operation.
+	state := make([]int64, n) // |V| · Slice of vertex -> dist<<32 | source index
+	for i := range state {
+		state[i] = unvisited
+	}
+
+	frontier := ws[0].b[:0] // |V|w · Vertices of the current level
+
+	for i, s := range sources {
+		if state[s] == unvisited {
+			state[s] = int64(i)
+			frontier = append(frontier, s)
+		}
+	}
+
+	shards := make([][]int, len(ws))
+	var wg sync.WaitGroup
+
+	for d := int64(1); len(frontier) > 0; d++ {
+		k := len(ws)
+		if k > len(frontier) {
+			k = len(frontier)
+		}
+
+		for i := 0; i < k; i++ {
+			lo, hi := len(frontier)*i/k, len(frontier)*(i+1)/k
+			wg.Add(1)
+
+			go func(i int, part []int) {
+				shards[i] = exploreLevel(g, state, part, ws[i].a[:0], d)
+				wg.Done()
+			}(i, frontier[lo:hi])
+		}
+
+		wg.Wait()
+
+		frontier = frontier[:0]
+		for i := 0; i < k; i++ {
+			frontier = append(frontier, shards[i]...)
+		}
+	}
+
+	dist = resizeIntSlice(dist, n)
+	nearest = resizeIntSlice(nearest, n)
+
+	for v, x := range state {
+		if x == unvisited {
+			dist[v], nearest[v] = undefined, undefined
+		} else {
+			dist[v], nearest[v] = int(x>>32), sources[x&math.MaxUint32]
+		}
+	}
+
+	return dist, nearest
+}
+
+// unvisited is the packed state of a vertex that has not been reached by
+// ParallelMultiSourceBFS. It is greater than every valid state.
+const unvisited = math.MaxInt64
+
+// exploreLevel visits the edges of the vertices in part, which are at
+// distance d-1, and appends every vertex that it claims for distance d to
+// next. A vertex that is already claimed for distance d is updated to the
+// earliest nearest source.
+func exploreLevel[A Adjacency](g A, state []int64, part, next []int, d int64) []int {
+	for _, u := range part {
+		x := d<<32 | atomic.LoadInt64(&state[u])&math.MaxUint32
+
+		for _, v := range g.Neighbors(u) {
+			for {
+				old := atomic.LoadInt64(&state[v])
+				if old <= x {
+					break
+				}
+				if atomic.CompareAndSwapInt64(&state[v], old, x) {
+					if old == unvisited {
+						next = append(next, v)
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return next
+}
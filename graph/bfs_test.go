@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGraphMultiSourceBFS(t *testing.T) {
+	data := []struct {
+		size    int
+		edges   [][2]int
+		sources []int
+		dist    []int
+		nearest []int
+	}{
+		{size: 0, edges: [][2]int{}, sources: []int{}, dist: []int{}, nearest: []int{}},
+		{size: 2, edges: [][2]int{}, sources: []int{}, dist: []int{-1, -1}, nearest: []int{-1, -1}},
+		{
+			size:    3,
+			edges:   [][2]int{{0, 1}, {1, 2}},
+			sources: []int{0},
+			dist:    []int{0, 1, 2},
+			nearest: []int{0, 0, 0},
+		},
+		{
+			// 4 is equidistant from 0 and 2; 2 appears first in sources.
+			size:    6,
+			edges:   [][2]int{{0, 1}, {1, 4}, {2, 3}, {3, 4}, {4, 5}},
+			sources: []int{2, 0, 2},
+			dist:    []int{0, 1, 0, 1, 2, 3},
+			nearest: []int{0, 0, 2, 2, 2, 2},
+		},
+		{
+			// Directed edges only
+			size:    4,
+			edges:   [][2]int{{1, 0}, {2, 3}},
+			sources: []int{0, 3},
+			dist:    []int{0, -1, -1, 0},
+			nearest: []int{0, -1, -1, 3},
+		},
+	}
+
+	w := NewWorkspace(0)
+	ws := []*Workspace{NewWorkspace(0), NewWorkspace(0), NewWorkspace(0)}
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+		for _, e := range row.edges {
+			g.AddEdge(e[0], e[1])
+		}
+
+		dist, nearest := g.MultiSourceBFS(nil, nil, row.sources, w)
+
+		if !reflect.DeepEqual(dist, row.dist) && len(dist)+len(row.dist) > 0 {
+			t.Errorf("%v != %v", dist, row.dist)
+		}
+		if !reflect.DeepEqual(nearest, row.nearest) && len(nearest)+len(row.nearest) > 0 {
+			t.Errorf("%v != %v", nearest, row.nearest)
+		}
+
+		pdist, pnearest := ParallelMultiSourceBFS(g, dist, nearest, row.sources, ws)
+
+		if !reflect.DeepEqual(pdist, row.dist) && len(pdist)+len(row.dist) > 0 {
+			t.Errorf("%v != %v", pdist, row.dist)
+		}
+		if !reflect.DeepEqual(pnearest, row.nearest) && len(pnearest)+len(row.nearest) > 0 {
+			t.Errorf("%v != %v", pnearest, row.nearest)
+		}
+	}
+}
+
+func TestParallelMultiSourceBFSRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 50; trial++ {
+		size := r.Intn(2000) + 1
+		g := make(Graph, size)
+
+		for i := 0; i < size*3; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+
+		sources := make([]int, r.Intn(5)+1)
+		for i := range sources {
+			sources[i] = r.Intn(size)
+		}
+
+		ws := make([]*Workspace, r.Intn(8)+1)
+		for i := range ws {
+			ws[i] = NewWorkspace(0)
+		}
+
+		dist, nearest := g.MultiSourceBFS(nil, nil, sources, w)
+		pdist, pnearest := ParallelMultiSourceBFS(NewCSR(g), nil, nil, sources, ws)
+
+		if !reflect.DeepEqual(dist, pdist) {
+			t.Errorf("%v != %v", dist, pdist)
+		}
+		if !reflect.DeepEqual(nearest, pnearest) {
+			t.Errorf("%v != %v", nearest, pnearest)
+		}
+
+		// Single-source BFS agrees with LeastEdgesPath
+		u, v := sources[0], r.Intn(size)
+		dist, _ = g.MultiSourceBFS(dist, nearest, sources[:1], w)
+		if path := g.LeastEdgesPath(nil, u, v, w); u != v && len(path)-1 != dist[v] {
+			t.Errorf("%v != %v", len(path)-1, dist[v])
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import "github.com/guns/golibs/generic/impl"
+
+// WeaklyConnectedComponents returns the weakly connected components of the
+// graph; i.e. the connected components of the graph with edge directions
+// ignored. The graph does not need to be symmetric.
+//
+// Each component is a sorted slice of vertex indices, and components are
+// ordered by their smallest vertex.
+//
+// Note that the returned [][]int is backed by a single []int
+// (wcc[0][:cap(wcc[0])]), so passing the same [][]int as the wcc parameter
+// reuses memory.
+func (g Graph) WeaklyConnectedComponents(wcc [][]int, w *Workspace) [][]int {
+	return WeaklyConnectedComponents(g, wcc, w)
+}
+
+// WeaklyConnectedComponents is like (Graph).WeaklyConnectedComponents, but
+// accepts any Adjacency.
+func WeaklyConnectedComponents[A Adjacency](g A, wcc [][]int, w *Workspace) [][]int {
+	n := g.Len()
+	w.prepare(n, 0)
+
+	uf := w.makeUnionFind(wA | wB) // 2|V|w · Union-find of vertex -> component
+	root := w.c                    // |V|w  · Slice of vertex -> representative
+
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
+			uf.Union(u, v)
+		}
+	}
+
+	for v := range root {
+		root[v] = uf.Find(v)
+	}
+
+	// The union-find is no longer needed, so build a circular linked list
+	// of the members of each component in ascending order.
+	next := w.a // |V|w · Slice of vertex -> next member of component
+	tail := w.b // |V|w · Slice of representative -> last member of component
+
+	for v := range tail {
+		tail[v] = undefined
+	}
+
+	for v := 0; v < n; v++ {
+		r := root[v]
+
+		if t := tail[r]; t == undefined {
+			next[v] = v
+		} else {
+			next[v] = next[t]
+			next[t] = v
+		}
+
+		tail[r] = v
+	}
+
+	builder := impl.NewPacked2DIntBuilderFromRows(wcc)
+	builder.Grow(n - builder.Cap())
+	builder.SetAutoGrow(false)
+
+	for u := 0; u < n; u++ {
+		r := root[u]
+		if tail[r] == undefined || next[tail[r]] != u {
+			// Not the head of an unwritten component
+			continue
+		}
+
+		for v := u; ; {
+			builder.Append(v)
+			if v == tail[r] {
+				break
+			}
+			v = next[v]
+		}
+
+		builder.FinishRow()
+		tail[r] = undefined
+	}
+
+	return builder.Rows
+}
+
+// Bridges returns the bridges of an undirected graph; i.e. the edges whose
+// removal increases the number of connected components.
+//
+// An undirected graph is represented by adding both (u, v) and (v, u) for
+// every edge, and only one direction of each bridge is returned. Parallel
+// edges are never bridges.
+//
+// The bridges are written to the bridges slice, which is grown if necessary.
+func (g Graph) Bridges(bridges []Edge, w *Workspace) []Edge {
+	return Bridges(g, bridges, w)
+}
+
+// ArticulationPoints returns the articulation points of an undirected graph;
+// i.e. the vertices whose removal increases the number of connected
+// components. The graph must be symmetric, as with Bridges.
+//
+// The vertices are written in ascending order to the points slice, which is
+// grown if necessary.
+func (g Graph) ArticulationPoints(points []int, w *Workspace) []int {
+	return ArticulationPoints(g, points, w)
+}
+
+// Bridges is like (Graph).Bridges, but accepts any Adjacency. The index I of
+// each returned Edge is an index into g.Neighbors(U).
+func Bridges[A Adjacency](g A, bridges []Edge, w *Workspace) []Edge {
+	bridges, _ = lowLink(g, bridges[:0], nil, false, w)
+	return bridges
+}
+
+// ArticulationPoints is like (Graph).ArticulationPoints, but accepts any
+// Adjacency.
+func ArticulationPoints[A Adjacency](g A, points []int, w *Workspace) []int {
+	_, points = lowLink(g, nil, points[:0], true, w)
+
+	// Vertices may be found more than once.
+	impl.QuicksortIntSlice(points)
+
+	k := 0
+	for i, v := range points {
+		if i == 0 || v != points[k-1] {
+			points[k] = v
+			k++
+		}
+	}
+
+	return points[:k]
+}
+
+// lowLink is an iterative implementation of Tarjan's bridge-finding and
+// articulation point algorithms for a symmetric graph. If findPoints is true,
+// articulation points are appended to points, possibly more than once.
+// Otherwise, bridges are appended to bridges.
+//
+// Instead of preorder indices, a vertex on the current DFS path is identified
+// by its depth, which is equivalent because every non-tree edge of an
+// undirected DFS leads to an ancestor or a descendant. The low-link of each
+// vertex is the smallest depth reachable from its subtree with a single back
+// edge.
+func lowLink[A Adjacency](g A, bridges []Edge, points []int, findPoints bool, w *Workspace) ([]Edge, []int) {
+	n := g.Len()
+	w.prepare(n, wANeg|wD)
+
+	depth := w.a // |V|w · Slice of vertex -> depth if active, n if explored
+	path := w.b  // |V|w · Slice of depth -> vertex on the DFS path
+	next := w.c  // |V|w · Slice of depth -> index of next edge to explore
+	low := w.d   // |V|w · Slice of depth -> low-link depth
+
+	for r := 0; r < n; r++ {
+		if depth[r] != undefined {
+			continue
+		}
+
+		depth[r] = 0
+		path[0] = r
+		next[0] = 0
+		low[0] = 0
+		children := 0 // Number of tree edges from r
+		d := 0        // Depth of the top of the DFS path
+
+		for d >= 0 {
+			u := path[d]
+			edges := g.Neighbors(u)
+
+			if next[d] == len(edges) {
+				// Post-order visit; compare the low-link of u against
+				// its parent.
+				depth[u] = n
+				d--
+
+				if d < 0 {
+					break
+				}
+
+				if low[d+1] < low[d] {
+					low[d] = low[d+1]
+				}
+
+				if !findPoints && low[d+1] > d {
+					bridges = append(bridges, Edge{U: path[d], V: u, I: next[d]})
+				}
+
+				if findPoints && low[d+1] >= d && d > 0 {
+					points = append(points, path[d])
+				}
+
+				if d == 0 {
+					children++
+				}
+
+				next[d]++
+				continue
+			}
+
+			v := edges[next[d]]
+
+			if d > 0 && v == path[d-1] {
+				// Edges to the parent are handled on entry
+				next[d]++
+				continue
+			}
+
+			if depth[v] != undefined {
+				// Back edge, or an edge to an explored descendant
+				if depth[v] < low[d] {
+					low[d] = depth[v]
+				}
+				next[d]++
+				continue
+			}
+
+			// Tree edge; next[d] is advanced when v is explored.
+			d++
+			depth[v] = d
+			path[d] = v
+			next[d] = 0
+			low[d] = d
+
+			// A parallel edge to the parent is a back edge.
+			parallel := -1
+			for _, x := range g.Neighbors(v) {
+				if x == u {
+					parallel++
+				}
+			}
+			if parallel > 0 {
+				low[d] = d - 1
+			}
+		}
+
+		if findPoints && children > 1 {
+			points = append(points, r)
+		}
+	}
+
+	return bridges, points
+}
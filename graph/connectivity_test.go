@@ -0,0 +1,215 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGraphWeaklyConnectedComponents(t *testing.T) {
+	data := []struct {
+		size  int
+		edges [][2]int
+		wcc   [][]int
+	}{
+		{size: 0, edges: [][2]int{}, wcc: [][]int{}},
+		{size: 1, edges: [][2]int{}, wcc: [][]int{{0}}},
+		{size: 3, edges: [][2]int{}, wcc: [][]int{{0}, {1}, {2}}},
+		{size: 3, edges: [][2]int{{2, 0}}, wcc: [][]int{{0, 2}, {1}}},
+		{
+			size:  7,
+			edges: [][2]int{{6, 1}, {3, 1}, {4, 0}, {5, 5}, {2, 4}},
+			wcc:   [][]int{{0, 2, 4}, {1, 3, 6}, {5}},
+		},
+	}
+
+	w := NewWorkspace(0)
+	wcc := [][]int{}
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+		for _, e := range row.edges {
+			g.AddEdge(e[0], e[1])
+		}
+
+		wcc = g.WeaklyConnectedComponents(wcc, w)
+
+		if len(wcc) == 0 && len(row.wcc) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(wcc, row.wcc) {
+			t.Errorf("%v != %v", wcc, row.wcc)
+		}
+	}
+}
+
+func TestGraphBridgesAndArticulationPoints(t *testing.T) {
+	data := []struct {
+		size    int
+		edges   [][2]int
+		bridges [][2]int
+		points  []int
+	}{
+		{size: 0, edges: [][2]int{}, bridges: [][2]int{}, points: []int{}},
+		{size: 2, edges: [][2]int{{0, 1}}, bridges: [][2]int{{0, 1}}, points: []int{}},
+		{size: 2, edges: [][2]int{{0, 1}, {0, 1}}, bridges: [][2]int{}, points: []int{}},
+		{size: 2, edges: [][2]int{{0, 0}, {1, 1}}, bridges: [][2]int{}, points: []int{}},
+		{size: 3, edges: [][2]int{{0, 1}, {1, 2}}, bridges: [][2]int{{1, 2}, {0, 1}}, points: []int{1}},
+		{size: 3, edges: [][2]int{{0, 1}, {1, 2}, {2, 0}}, bridges: [][2]int{}, points: []int{}},
+		{
+			// Two triangles joined by a bridge, plus a pendant vertex
+			size:    7,
+			edges:   [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 5}, {5, 3}, {5, 6}},
+			bridges: [][2]int{{5, 6}, {2, 3}},
+			points:  []int{2, 3, 5},
+		},
+		{
+			// Bowtie: two triangles sharing vertex 0
+			size:    5,
+			edges:   [][2]int{{0, 1}, {1, 2}, {2, 0}, {0, 3}, {3, 4}, {4, 0}},
+			bridges: [][2]int{},
+			points:  []int{0},
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+		for _, e := range row.edges {
+			g.AddEdge(e[0], e[1])
+			g.AddEdge(e[1], e[0])
+		}
+
+		bridges := g.Bridges(nil, w)
+		pairs := [][2]int{}
+		for _, e := range bridges {
+			if g[e.U][e.I] != e.V {
+				t.Errorf("%v is not an edge", e)
+			}
+			pairs = append(pairs, [2]int{e.U, e.V})
+		}
+
+		if !reflect.DeepEqual(pairs, row.bridges) {
+			t.Errorf("%v != %v", pairs, row.bridges)
+		}
+
+		if points := g.ArticulationPoints([]int{}, w); !reflect.DeepEqual(points, row.points) {
+			t.Errorf("%v != %v", points, row.points)
+		}
+	}
+}
+
+// countComponents returns the number of connected components of a symmetric
+// graph without vertex x and without the edge (u, v) in either direction.
+func countComponents(g Graph, x, u, v int) int {
+	h := make(Graph, len(g))
+
+	for a := range g {
+		for _, b := range g[a] {
+			if a == x || b == x || (a == u && b == v) || (a == v && b == u) {
+				continue
+			}
+			h.AddEdge(a, b)
+		}
+	}
+
+	n := len(h.WeaklyConnectedComponents(nil, NewWorkspace(0)))
+	if x != undefined {
+		n-- // x is isolated
+	}
+
+	return n
+}
+
+func TestGraphBridgesAndArticulationPointsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 100; trial++ {
+		size := r.Intn(12) + 1
+		g := make(Graph, size)
+
+		for i := r.Intn(size * 2); i > 0; i-- {
+			u, v := r.Intn(size), r.Intn(size)
+			g.AddEdge(u, v)
+			g.AddEdge(v, u)
+		}
+
+		base := countComponents(g, undefined, undefined, undefined)
+
+		// Reference implementation by removal
+		bridges := map[[2]int]bool{}
+		for u := range g {
+			for _, v := range g[u] {
+				if u < v && countComponents(g, undefined, u, v) > base {
+					mult := 0
+					for _, x := range g[u] {
+						if x == v {
+							mult++
+						}
+					}
+					if mult == 1 {
+						bridges[[2]int{u, v}] = true
+					}
+				}
+			}
+		}
+
+		points := []int{}
+		for x := range g {
+			if countComponents(g, x, undefined, undefined) > base {
+				points = append(points, x)
+			}
+		}
+
+		found := map[[2]int]bool{}
+		for _, e := range g.Bridges(nil, w) {
+			u, v := e.U, e.V
+			if u > v {
+				u, v = v, u
+			}
+			found[[2]int{u, v}] = true
+		}
+
+		if !reflect.DeepEqual(found, bridges) {
+			t.Errorf("%v: %v != %v", g, found, bridges)
+		}
+
+		if p := g.ArticulationPoints(nil, w); !reflect.DeepEqual(p, points) && len(p)+len(points) > 0 {
+			t.Errorf("%v: %v != %v", g, p, points)
+		}
+
+		c := NewCSR(g)
+		if p, q := c.ArticulationPoints(nil, w), g.ArticulationPoints(nil, w); !reflect.DeepEqual(p, q) {
+			t.Errorf("%v != %v", p, q)
+		}
+		if wcc := c.WeaklyConnectedComponents(nil, w); !reflect.DeepEqual(wcc, g.WeaklyConnectedComponents(nil, w)) {
+			t.Errorf("%v != %v", wcc, g.WeaklyConnectedComponents(nil, w))
+		}
+	}
+}
+
+func TestGraphBridgesDeep(t *testing.T) {
+	// A long path must not overflow any stack.
+	const size = 1 << 18
+
+	g := make(Graph, size)
+	for u := 0; u < size-1; u++ {
+		g.AddEdge(u, u+1)
+		g.AddEdge(u+1, u)
+	}
+
+	w := NewWorkspace(size)
+
+	if n := len(g.Bridges(nil, w)); n != size-1 {
+		t.Errorf("%v != %v", n, size-1)
+	}
+	if n := len(g.ArticulationPoints(nil, w)); n != size-2 {
+		t.Errorf("%v != %v", n, size-2)
+	}
+}
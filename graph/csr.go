@@ -0,0 +1,201 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+// A CSR is an immutable graph in compressed sparse row form.
+//
+// The edges of all vertices are stored contiguously in a single slice, and
+// the edges of vertex u are edges[offsets[u]:offsets[u+1]]. Compared to a
+// Graph, this saves a slice header per vertex and keeps adjacency lists
+// adjacent in memory, which improves the cache behavior of traversals over
+// large graphs.
+//
+// The zero value is an empty graph.
+type CSR struct {
+	offsets []int // |V|+1 · Slice of vertex -> index of first edge in edges
+	edges   []int // |E|   · Heads of all edges, grouped by tail
+}
+
+// NewCSR returns a copy of g in compressed sparse row form. The order of the
+// edges of each vertex is preserved.
+func NewCSR(g Graph) CSR {
+	m := 0
+	for u := range g {
+		m += len(g[u])
+	}
+
+	c := CSR{
+		offsets: make([]int, len(g)+1),
+		edges:   make([]int, 0, m),
+	}
+
+	for u := range g {
+		c.edges = append(c.edges, g[u]...)
+		c.offsets[u+1] = len(c.edges)
+	}
+
+	return c
+}
+
+// NewCSRFromEdgeList returns a graph of size vertices in compressed sparse
+// row form with the edges (tails[i], heads[i]). The order of the edges of each
+// vertex is preserved. This avoids the construction of an intermediate Graph.
+func NewCSRFromEdgeList(size int, tails, heads []int) CSR {
+	c := CSR{
+		offsets: make([]int, size+1),
+		edges:   make([]int, len(heads)),
+	}
+
+	off := c.offsets
+
+	// Count out-degrees
+	for _, u := range tails {
+		off[u+1]++
+	}
+
+	// Prefix sums
+	for u := 1; u < len(off); u++ {
+		off[u] += off[u-1]
+	}
+
+	// Place each edge at the cursor of its tail, advancing the cursor
+	for i, u := range tails {
+		c.edges[off[u]] = heads[i]
+		off[u]++
+	}
+
+	// Each cursor now points to the start of the next vertex's edges.
+	for u := len(off) - 1; u > 0; u-- {
+		off[u] = off[u-1]
+	}
+	off[0] = 0
+
+	return c
+}
+
+// Len returns the number of vertices in the graph.
+func (c CSR) Len() int {
+	if len(c.offsets) == 0 {
+		return 0
+	}
+	return len(c.offsets) - 1
+}
+
+// NumEdges returns the number of edges in the graph.
+func (c CSR) NumEdges() int {
+	return len(c.edges)
+}
+
+// Neighbors returns the heads of all edges from vertex u. The returned slice
+// must not be modified, but its capacity is limited to its length, so
+// appending to it never overwrites the edges of another vertex.
+func (c CSR) Neighbors(u int) []int {
+	a, b := c.offsets[u], c.offsets[u+1]
+	return c.edges[a:b:b]
+}
+
+// Graph returns a copy of c as a Graph.
+func (c CSR) Graph() Graph {
+	g := make(Graph, c.Len())
+
+	for u := range g {
+		g[u] = append([]int(nil), c.Neighbors(u)...)
+	}
+
+	return g
+}
+
+// LeastEdgesPath is equivalent to (Graph).LeastEdgesPath.
+func (c CSR) LeastEdgesPath(path []int, u, v int, w *Workspace) []int {
+	return LeastEdgesPath(c, path, u, v, w)
+}
+
+// TopologicalSort is equivalent to (Graph).TopologicalSort.
+func (c CSR) TopologicalSort(tsort []int, w *Workspace) []int {
+	return TopologicalSort(c, tsort, w)
+}
+
+// TopologicalSortOrCycle is equivalent to (Graph).TopologicalSortOrCycle.
+func (c CSR) TopologicalSortOrCycle(tsort, cycle []int, w *Workspace) ([]int, []int) {
+	return TopologicalSortOrCycle(c, tsort, cycle, w)
+}
+
+// StronglyConnectedComponents is equivalent to
+// (Graph).StronglyConnectedComponents.
+func (c CSR) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
+	return StronglyConnectedComponents(c, scc, w)
+}
+
+// WeaklyConnectedComponents is equivalent to
+// (Graph).WeaklyConnectedComponents.
+func (c CSR) WeaklyConnectedComponents(wcc [][]int, w *Workspace) [][]int {
+	return WeaklyConnectedComponents(c, wcc, w)
+}
+
+// Bridges is equivalent to (Graph).Bridges.
+func (c CSR) Bridges(bridges []Edge, w *Workspace) []Edge {
+	return Bridges(c, bridges, w)
+}
+
+// ArticulationPoints is equivalent to (Graph).ArticulationPoints.
+func (c CSR) ArticulationPoints(points []int, w *Workspace) []int {
+	return ArticulationPoints(c, points, w)
+}
+
+// BFS is equivalent to (Graph).BFS.
+func (c CSR) BFS(sources []int, vis *Visitor, w *Workspace) bool {
+	return BFS(c, sources, vis, w)
+}
+
+// DFS is equivalent to (Graph).DFS.
+func (c CSR) DFS(sources []int, vis *Visitor, w *Workspace) bool {
+	return DFS(c, sources, vis, w)
+}
+
+// WLHash is equivalent to (Graph).WLHash.
+func (c CSR) WLHash(rounds int, w *Workspace) uint64 {
+	return WLHash(c, rounds, w)
+}
+
+// Isomorphism is equivalent to (Graph).Isomorphism.
+func (c CSR) Isomorphism(h CSR, mapping []int, w *Workspace) ([]int, bool) {
+	return Isomorphism(c, h, mapping, w)
+}
+
+// Transpose writes to t a copy of the current graph with all edges reversed.
+// The memory of t is reused if possible. As with (Graph).Transpose, the
+// edges of each vertex of the result are ordered by tail.
+func (c CSR) Transpose(t CSR) CSR {
+	n := c.Len()
+
+	t.offsets = resizeIntSlice(t.offsets, n+1)
+	for i := range t.offsets {
+		t.offsets[i] = 0
+	}
+	t.edges = resizeIntSlice(t.edges, len(c.edges))
+
+	// Counting sort by head, as in NewCSRFromEdgeList. Visiting edges in
+	// order of tail keeps the sort stable.
+	off := t.offsets
+
+	for _, v := range c.edges {
+		off[v+1]++
+	}
+	for v := 1; v < len(off); v++ {
+		off[v] += off[v-1]
+	}
+	for u := 0; u < n; u++ {
+		for _, v := range c.Neighbors(u) {
+			t.edges[off[v]] = u
+			off[v]++
+		}
+	}
+	for v := len(off) - 1; v > 0; v-- {
+		off[v] = off[v-1]
+	}
+	off[0] = 0
+
+	return t
+}
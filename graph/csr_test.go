@@ -0,0 +1,107 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCSR(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 50; trial++ {
+		size := r.Intn(20)
+		g := make(Graph, size)
+		var tails, heads []int
+
+		for i := 0; size > 0 && i < size*2; i++ {
+			u, v := r.Intn(size), r.Intn(size)
+			if trial%2 == 0 && u >= v {
+				// Keep every other graph acyclic
+				continue
+			}
+			g.AddEdge(u, v)
+			tails = append(tails, u)
+			heads = append(heads, v)
+		}
+
+		c := NewCSR(g)
+		d := NewCSRFromEdgeList(size, tails, heads)
+
+		if !reflect.DeepEqual(c, d) {
+			t.Errorf("%v != %v", c, d)
+		}
+		if c.Len() != len(g) {
+			t.Errorf("%v != %v", c.Len(), len(g))
+		}
+		if c.NumEdges() != len(tails) {
+			t.Errorf("%v != %v", c.NumEdges(), len(tails))
+		}
+
+		h := c.Graph()
+		for u := range g {
+			if len(g[u]) == 0 {
+				g[u] = nil
+			}
+		}
+		if !reflect.DeepEqual(h, g) {
+			t.Errorf("%v != %v", h, g)
+		}
+
+		// Appending to the edges of a vertex must not clobber the next
+		for u := 0; u < c.Len(); u++ {
+			if edges := c.Neighbors(u); cap(edges) != len(edges) {
+				t.Errorf("%v != %v", cap(edges), len(edges))
+			}
+		}
+		if size > 1 {
+			_ = append(c.Neighbors(0), -1)
+			if !reflect.DeepEqual(c, d) {
+				t.Errorf("%v != %v", c, d)
+			}
+		}
+
+		// Transpose
+		gT := g.Transpose(make(Graph, 0, size))
+		cT := c.Transpose(CSR{})
+		if !reflect.DeepEqual(cT.Graph(), gT) {
+			t.Errorf("%v != %v", cT.Graph(), gT)
+		}
+		if cT = c.Transpose(cT); !reflect.DeepEqual(cT.Graph(), gT) {
+			t.Errorf("%v != %v", cT.Graph(), gT)
+		}
+
+		// Algorithms must agree with Graph
+		for i := 0; i < size; i++ {
+			u, v := r.Intn(size), r.Intn(size)
+			p := g.LeastEdgesPath(nil, u, v, w)
+			q := c.LeastEdgesPath(nil, u, v, w)
+			if !reflect.DeepEqual(p, q) {
+				t.Errorf("%v != %v", p, q)
+			}
+		}
+
+		gtsort, gcycle := g.TopologicalSortOrCycle(nil, nil, w)
+		ctsort, ccycle := c.TopologicalSortOrCycle(nil, nil, w)
+		if !reflect.DeepEqual(gtsort, ctsort) {
+			t.Errorf("%v != %v", gtsort, ctsort)
+		}
+		if !reflect.DeepEqual(gcycle, ccycle) {
+			t.Errorf("%v != %v", gcycle, ccycle)
+		}
+		if tsort := c.TopologicalSort(nil, w); !reflect.DeepEqual(tsort, gtsort) {
+			t.Errorf("%v != %v", tsort, gtsort)
+		}
+
+		gscc := g.StronglyConnectedComponents(nil, w)
+		cscc := c.StronglyConnectedComponents(nil, w)
+		if !reflect.DeepEqual(gscc, cscc) {
+			t.Errorf("%v != %v", gscc, cscc)
+		}
+	}
+}
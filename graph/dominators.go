@@ -0,0 +1,122 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+// Dominators computes the immediate dominators of a flow graph with entry
+// vertex root. A vertex d dominates v if every path from root to v passes
+// through d, and the immediate dominator of v is the unique dominator of v
+// that is dominated by every other dominator of v, except v itself.
+//
+// The immediate dominator of each vertex is written to idom, which is grown
+// if necessary: idom[root] == root, and idom[v] == -1 if v is unreachable from
+// root. The dominator tree, which has an edge from idom[v] to v for every
+// reachable vertex v other than root, is written to tree, which is also grown
+// if necessary. The children of each vertex in tree are sorted.
+//
+// This is the iterative algorithm of Cooper, Harvey, and Kennedy [1], which
+// is simple and fast for the small, shallow graphs typical of control flow.
+// Note that the predecessors of every vertex are found with Transpose, which
+// allocates a Graph.
+//
+// [1]: https://www.cs.rice.edu/~keith/EMBED/dom.pdf
+func (g Graph) Dominators(idom []int, tree Graph, root int, w *Workspace) ([]int, Graph) {
+	n := len(g)
+	w.prepare(n, wANeg|wC)
+
+	post := w.a              // |V|w · Slice of vertex -> postorder index
+	stack := w.makeStack(wB) // |V|w · DFS stack of active vertices
+	next := w.c              // |V|w · Slice of vertex -> index of next edge to explore
+
+	idom = resizeIntSlice(idom, n)
+	order := idom[:0] // Vertices in postorder; idom is not needed yet
+
+	// Iterative DFS from root, as in TopologicalSort
+	post[root] = n // Visited, but not yet numbered
+	stack.Push(root)
+
+	for stack.Len() > 0 {
+		u := stack.Peek()
+
+		if next[u] == len(g[u]) {
+			stack.Pop()
+			post[u] = len(order)
+			order = append(order, u)
+			continue
+		}
+
+		v := g[u][next[u]]
+		next[u]++
+
+		if post[v] == undefined {
+			post[v] = n
+			stack.Push(v)
+		}
+	}
+
+	// Move the postorder out of idom
+	rpo := w.b[:len(order)] // |V|w · Vertices in postorder, visited in reverse
+	copy(rpo, order)
+
+	for v := range idom {
+		idom[v] = undefined
+	}
+	idom[root] = root
+
+	pred := g.Transpose(nil)
+
+	for changed := true; changed; {
+		changed = false
+
+		// Reverse postorder, skipping root
+		for i := len(rpo) - 2; i >= 0; i-- {
+			v := rpo[i]
+			d := undefined
+
+			for _, p := range pred[v] {
+				if idom[p] == undefined {
+					// Unreachable, or not yet processed
+					continue
+				}
+
+				if d == undefined {
+					d = p
+					continue
+				}
+
+				// Intersect
+				for p != d {
+					for post[p] < post[d] {
+						p = idom[p]
+					}
+					for post[d] < post[p] {
+						d = idom[d]
+					}
+				}
+			}
+
+			if idom[v] != d {
+				idom[v] = d
+				changed = true
+			}
+		}
+	}
+
+	if cap(tree) >= n {
+		tree = tree[:n]
+	} else {
+		tree = make(Graph, n)
+	}
+	for u := range tree {
+		tree[u] = tree[u][:0]
+	}
+
+	for v, d := range idom {
+		if d != undefined && v != root {
+			tree.AddEdge(d, v)
+		}
+	}
+
+	return idom, tree
+}
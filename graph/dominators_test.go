@@ -0,0 +1,161 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGraphDominators(t *testing.T) {
+	data := []struct {
+		g    Graph
+		root int
+		idom []int
+		tree Graph
+	}{
+		{g: Graph{{}}, root: 0, idom: []int{0}, tree: Graph{nil}},
+		{g: Graph{{0}, {0}}, root: 0, idom: []int{0, -1}, tree: Graph{nil, nil}},
+		{
+			// Diamond with a loop back to the entry
+			g:    Graph{{1, 2}, {3}, {3}, {0}},
+			root: 0,
+			idom: []int{0, 0, 0, 0},
+			tree: Graph{{1, 2, 3}, nil, nil, nil},
+		},
+		{
+			// Figure 2 of Cooper, Harvey, and Kennedy, with vertex 5 as
+			// the entry
+			g:    Graph{{}, {2}, {1}, {2}, {1, 3}, {4, 3}},
+			root: 5,
+			idom: []int{-1, 5, 5, 5, 5, 5},
+			tree: Graph{nil, nil, nil, nil, nil, {1, 2, 3, 4}},
+		},
+		{
+			// Nested loops
+			g:    Graph{{1}, {2}, {3, 1}, {4, 2}, {}},
+			root: 0,
+			idom: []int{0, 0, 1, 2, 3},
+			tree: Graph{{1}, {2}, {3}, {4}, nil},
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		idom, tree := row.g.Dominators(nil, nil, row.root, w)
+
+		if !reflect.DeepEqual(idom, row.idom) {
+			t.Errorf("%v != %v", idom, row.idom)
+		}
+		if !reflect.DeepEqual(tree, row.tree) {
+			t.Errorf("%v != %v", tree, row.tree)
+		}
+	}
+}
+
+// reachableWithout returns the vertices reachable from root without passing
+// through vertex x.
+func reachableWithout(g Graph, root, x int) []bool {
+	seen := make([]bool, len(g))
+	if root == x {
+		return seen
+	}
+
+	seen[root] = true
+	stack := []int{root}
+
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, v := range g[u] {
+			if !seen[v] && v != x {
+				seen[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+
+	return seen
+}
+
+func TestGraphDominatorsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	var idom []int
+	var tree Graph
+
+	for trial := 0; trial < 100; trial++ {
+		size := r.Intn(30) + 1
+		g := make(Graph, size)
+		for i := 0; i < size*2; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+
+		root := r.Intn(size)
+		idom, tree = g.Dominators(idom, tree, root, w)
+
+		// dom[d][v] is true if d strictly dominates v
+		reach := reachableWithout(g, root, undefined)
+		dom := make([][]bool, size)
+		ndom := make([]int, size) // Number of strict dominators
+		for d := range g {
+			dom[d] = make([]bool, size)
+			without := reachableWithout(g, root, d)
+			for v := range g {
+				if v != d && reach[v] && !without[v] {
+					dom[d][v] = true
+					ndom[v]++
+				}
+			}
+		}
+
+		for v := range g {
+			want := undefined
+
+			switch {
+			case v == root:
+				want = root
+			case reach[v]:
+				// The strict dominator with the most dominators
+				for d := range g {
+					if dom[d][v] && (want == undefined || ndom[d] > ndom[want]) {
+						want = d
+					}
+				}
+			}
+
+			if idom[v] != want {
+				t.Errorf("%v: idom[%v]: %v != %v", g, v, idom[v], want)
+			}
+		}
+
+		edges := 0
+		for u := range tree {
+			for _, v := range tree[u] {
+				if idom[v] != u {
+					t.Errorf("%v != %v", idom[v], u)
+				}
+				edges++
+			}
+		}
+		if edges != countReachable(reach)-1 {
+			t.Errorf("%v != %v", edges, countReachable(reach)-1)
+		}
+	}
+}
+
+func countReachable(reach []bool) int {
+	n := 0
+	for _, ok := range reach {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
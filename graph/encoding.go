@@ -0,0 +1,317 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DOTOptions control the output of WriteDOT. The zero value writes a plain
+// directed graph.
+type DOTOptions struct {
+	Name       string  // Name of the graph; "G" if empty
+	Path       []int   // Path to highlight, e.g. from LeastEdgesPath
+	Components [][]int // Groups of vertices to color, e.g. from StronglyConnectedComponents
+}
+
+// dotPalette is a Graphviz color scheme for DOTOptions.Components.
+const (
+	dotPalette     = "set312"
+	dotPaletteSize = 12
+)
+
+// WriteDOT writes g to wr in the Graphviz DOT language. Every vertex is
+// written, so isolated vertices are preserved.
+//
+// If opts.Path is not empty, the vertices and edges of the path are drawn in
+// bold red. If opts.Components is not empty, the vertices of each component
+// are filled with the next color of a 12-color palette.
+func WriteDOT(wr io.Writer, g Adjacency, opts *DOTOptions) error {
+	if opts == nil {
+		opts = &DOTOptions{}
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "G"
+	}
+
+	n := g.Len()
+
+	color := make([]int, n) // Slice of vertex -> palette index, or zero
+	for i, c := range opts.Components {
+		for _, v := range c {
+			color[v] = i%dotPaletteSize + 1
+		}
+	}
+
+	pathEdges := make(map[[2]int]bool, len(opts.Path))
+	for i := 1; i < len(opts.Path); i++ {
+		pathEdges[[2]int{opts.Path[i-1], opts.Path[i]}] = true
+	}
+
+	onPath := make([]bool, n)
+	for _, v := range opts.Path {
+		onPath[v] = true
+	}
+
+	buf := bufio.NewWriter(wr)
+
+	fmt.Fprintf(buf, "digraph %s {\n", strconv.Quote(name))
+
+	for u := 0; u < n; u++ {
+		var attrs []string
+
+		if color[u] > 0 {
+			attrs = append(attrs, fmt.Sprintf(`style=filled, fillcolor="/%s/%d"`, dotPalette, color[u]))
+		}
+		if onPath[u] {
+			attrs = append(attrs, "color=red, penwidth=2")
+		}
+
+		writeDOTStatement(buf, strconv.Itoa(u), attrs)
+	}
+
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
+			var attrs []string
+
+			if pathEdges[[2]int{u, v}] {
+				attrs = append(attrs, "color=red, penwidth=2")
+			}
+
+			writeDOTStatement(buf, fmt.Sprintf("%d -> %d", u, v), attrs)
+		}
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Flush()
+}
+
+func writeDOTStatement(buf *bufio.Writer, stmt string, attrs []string) {
+	buf.WriteByte('\t')
+	buf.WriteString(stmt)
+
+	if len(attrs) > 0 {
+		buf.WriteString(" [")
+		buf.WriteString(strings.Join(attrs, ", "))
+		buf.WriteByte(']')
+	}
+
+	buf.WriteString(";\n")
+}
+
+// WriteEdgeList writes g to wr as a plain text edge list. The first line is
+// the number of vertices, and each following line is a single edge "u v".
+// Edges are written in order, so the order of the edges of each vertex is
+// preserved by ReadEdgeList.
+func WriteEdgeList(wr io.Writer, g Adjacency) error {
+	buf := bufio.NewWriter(wr)
+	n := g.Len()
+
+	fmt.Fprintln(buf, n)
+
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
+			fmt.Fprintln(buf, u, v)
+		}
+	}
+
+	return buf.Flush()
+}
+
+// MaxEdgeListVertices is the maximum number of vertices accepted by
+// ReadEdgeList. The vertex count of an edge list is allocated up front, so it
+// is bounded to prevent a small corrupt input from exhausting memory. Larger
+// graphs can be read with ReadBinary, which only allocates memory in
+// proportion to the size of its input.
+const MaxEdgeListVertices = 1 << 24
+
+// ReadEdgeList reads a Graph in the format written by WriteEdgeList. Blank
+// lines and lines that begin with '#' are ignored. An error is returned if
+// the number of vertices is greater than MaxEdgeListVertices.
+func ReadEdgeList(r io.Reader) (Graph, error) {
+	var g Graph
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		s := strings.TrimSpace(scanner.Text())
+		if len(s) == 0 || s[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(s)
+		nums := make([]int, len(fields))
+
+		for i := range fields {
+			x, err := strconv.Atoi(fields[i])
+			if err != nil || x < 0 {
+				return nil, fmt.Errorf("graph: line %d: invalid vertex %q", line, fields[i])
+			}
+			nums[i] = x
+		}
+
+		if g == nil {
+			if len(nums) != 1 {
+				return nil, fmt.Errorf("graph: line %d: expected number of vertices", line)
+			}
+			if nums[0] > MaxEdgeListVertices {
+				return nil, fmt.Errorf("graph: line %d: too many vertices: %d", line, nums[0])
+			}
+			g = make(Graph, nums[0])
+			continue
+		}
+
+		if len(nums) != 2 {
+			return nil, fmt.Errorf("graph: line %d: expected edge", line)
+		}
+		if nums[0] >= len(g) || nums[1] >= len(g) {
+			return nil, fmt.Errorf("graph: line %d: vertex out of range", line)
+		}
+
+		g.AddEdge(nums[0], nums[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, errors.New("graph: missing number of vertices")
+	}
+
+	return g, nil
+}
+
+// binaryMagic identifies the binary adjacency format.
+const binaryMagic = "GRPH"
+
+// ErrInvalidBinary is returned by ReadBinary when the input is not a graph in
+// the binary adjacency format.
+var ErrInvalidBinary = errors.New("graph: invalid binary graph")
+
+// WriteBinary writes g to wr in a compact binary adjacency format: a magic
+// header, followed by the number of vertices, and then the out-degree and
+// heads of the edges of each vertex. All numbers are encoded as uvarints.
+func WriteBinary(wr io.Writer, g Adjacency) error {
+	buf := bufio.NewWriter(wr)
+	var scratch [binary.MaxVarintLen64]byte
+
+	putUvarint := func(x int) {
+		buf.Write(scratch[:binary.PutUvarint(scratch[:], uint64(x))])
+	}
+
+	buf.WriteString(binaryMagic)
+
+	n := g.Len()
+	putUvarint(n)
+
+	for u := 0; u < n; u++ {
+		edges := g.Neighbors(u)
+		putUvarint(len(edges))
+		for _, v := range edges {
+			putUvarint(v)
+		}
+	}
+
+	return buf.Flush()
+}
+
+// ReadBinary reads a Graph in the format written by WriteBinary.
+// ErrInvalidBinary is returned if the input is malformed or truncated, and
+// any other error from r is returned as is.
+func ReadBinary(r io.Reader) (Graph, error) {
+	buf := &binaryReader{r: bufio.NewReader(r)}
+
+	var magic [len(binaryMagic)]byte
+	if _, err := io.ReadFull(buf.r, magic[:]); err != nil {
+		return nil, binaryError(err)
+	}
+	if string(magic[:]) != binaryMagic {
+		return nil, ErrInvalidBinary
+	}
+
+	// Nothing is preallocated from the sizes in the input, so a corrupt
+	// size cannot trigger a huge allocation.
+	readUvarint := func(max int) (int, error) {
+		x, err := binary.ReadUvarint(buf)
+		if buf.err != nil {
+			return 0, buf.err
+		}
+		if err != nil || x > uint64(max) {
+			return 0, ErrInvalidBinary
+		}
+		return int(x), nil
+	}
+
+	const maxInt = int(^uint(0) >> 1)
+
+	n, err := readUvarint(maxInt)
+	if err != nil {
+		return nil, err
+	}
+
+	var g Graph
+
+	for u := 0; u < n; u++ {
+		d, err := readUvarint(maxInt)
+		if err != nil {
+			return nil, err
+		}
+
+		var edges []int
+
+		for i := 0; i < d; i++ {
+			v, err := readUvarint(n - 1)
+			if err != nil {
+				return nil, err
+			}
+			edges = append(edges, v)
+		}
+
+		g = append(g, edges)
+	}
+
+	if g == nil {
+		g = Graph{}
+	}
+
+	return g, nil
+}
+
+// binaryReader is an io.ByteReader that records any read error other than
+// io.EOF, so that I/O errors can be distinguished from malformed input.
+type binaryReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (b *binaryReader) ReadByte() (byte, error) {
+	c, err := b.r.ReadByte()
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return c, err
+}
+
+// binaryError returns ErrInvalidBinary if err indicates truncated input, and
+// err otherwise.
+func binaryError(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrInvalidBinary
+	}
+	return err
+}
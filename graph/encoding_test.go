@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var errTest = errors.New("test error")
+
+func TestWriteDOT(t *testing.T) {
+	g := Graph{{1}, {2}, {0}, {}}
+
+	buf := new(bytes.Buffer)
+	opts := &DOTOptions{
+		Name:       "test",
+		Path:       []int{0, 1, 2},
+		Components: [][]int{{0, 1, 2}, {3}},
+	}
+
+	if err := WriteDOT(buf, g, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	out := `digraph "test" {
+	0 [style=filled, fillcolor="/set312/1", color=red, penwidth=2];
+	1 [style=filled, fillcolor="/set312/1", color=red, penwidth=2];
+	2 [style=filled, fillcolor="/set312/1", color=red, penwidth=2];
+	3 [style=filled, fillcolor="/set312/2"];
+	0 -> 1 [color=red, penwidth=2];
+	1 -> 2 [color=red, penwidth=2];
+	2 -> 0;
+}
+`
+
+	if buf.String() != out {
+		t.Errorf("%v != %v", buf.String(), out)
+	}
+
+	buf.Reset()
+
+	if err := WriteDOT(buf, Graph{{0}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := "digraph \"G\" {\n\t0;\n\t0 -> 0;\n}\n"; buf.String() != out {
+		t.Errorf("%v != %v", buf.String(), out)
+	}
+}
+
+func TestReadEdgeList(t *testing.T) {
+	data := []struct {
+		in  string
+		g   Graph
+		err bool
+	}{
+		{in: "", err: true},
+		{in: "# empty\n0\n", g: Graph{}},
+		{in: "3\n0 1\n\n# comment\n  2 0  \n0 2\n", g: Graph{{1, 2}, nil, {0}}},
+		{in: "2\n0 2\n", err: true},
+		{in: "2\n0 -1\n", err: true},
+		{in: "2\n0 1 1\n", err: true},
+		{in: "2 2\n", err: true},
+		{in: "x\n", err: true},
+		{in: "99999999999999999\n", err: true},
+		{in: "99999999999999999999\n", err: true},
+	}
+
+	for _, row := range data {
+		g, err := ReadEdgeList(strings.NewReader(row.in))
+
+		if (err != nil) != row.err {
+			t.Errorf("%q: unexpected error: %v", row.in, err)
+		}
+		if !row.err && !reflect.DeepEqual(g, row.g) {
+			t.Errorf("%v != %v", g, row.g)
+		}
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 50; trial++ {
+		size := r.Intn(300)
+		g := make(Graph, size)
+
+		for i := 0; size > 0 && i < size*2; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+
+		buf := new(bytes.Buffer)
+
+		if err := WriteEdgeList(buf, g); err != nil {
+			t.Fatal(err)
+		}
+		if h, err := ReadEdgeList(buf); err != nil || !reflect.DeepEqual(h, g) {
+			t.Errorf("%v != %v (%v)", h, g, err)
+		}
+
+		buf.Reset()
+
+		if err := WriteBinary(buf, NewCSR(g)); err != nil {
+			t.Fatal(err)
+		}
+
+		b := buf.Bytes()
+
+		if h, err := ReadBinary(bytes.NewReader(b)); err != nil || !reflect.DeepEqual(h, g) {
+			t.Errorf("%v != %v (%v)", h, g, err)
+		}
+
+		// Truncated input
+		if len(b) > 5 {
+			if _, err := ReadBinary(bytes.NewReader(b[:len(b)-1])); err != ErrInvalidBinary {
+				t.Errorf("%v != %v", err, ErrInvalidBinary)
+			}
+		}
+	}
+
+	if _, err := ReadBinary(strings.NewReader("GRAPH")); err != ErrInvalidBinary {
+		t.Errorf("%v != %v", err, ErrInvalidBinary)
+	}
+
+	// Read errors are passed through
+	b := []byte("GRPH\x03\x01\x02")
+	for i := 0; i <= len(b); i++ {
+		r := io.MultiReader(bytes.NewReader(b[:i]), iotest.ErrReader(errTest))
+		if _, err := ReadBinary(r); err != errTest {
+			t.Errorf("%v != %v", err, errTest)
+		}
+	}
+}
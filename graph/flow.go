@@ -0,0 +1,282 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"github.com/guns/golibs/bitslice"
+	"github.com/guns/golibs/generic/impl"
+)
+
+// MaxFlow returns the value of a maximum flow from vertex s to vertex t, the
+// flow through each edge, and a minimum s-t cut.
+//
+// Edge capacities are read from capacity, which must be parallel to g; i.e.
+// capacity[u][i] is the capacity of the edge (u, g[u][i]). All capacities
+// must be non-negative.
+//
+// The flow through each edge is written to flow, which is parallel to g and
+// grown if necessary. Note that the returned [][]float64 is backed by a single
+// []float64 (flow[0][:cap(flow[0])]), so passing the same [][]float64 as the
+// flow parameter reuses memory.
+//
+// The source side of a minimum cut is written to cut, which is grown if
+// necessary: cut.Get(v) is true iff v is on the same side of the cut as s.
+// The edges from the source side to the other side are saturated, and the sum
+// of their capacities is equal to the value of the flow. If s == t, the value
+// is zero and cut contains the vertices reachable from s.
+func (g Graph) MaxFlow(flow [][]float64, cut bitslice.T, s, t int, capacity [][]float64, w *Workspace) (float64, [][]float64, bitslice.T) {
+	n := len(g)
+	w.prepare(n, 0)
+
+	// Dinic's algorithm
+
+	level := w.a // |V|w · Slice of vertex -> BFS level in the residual graph
+	arc := w.c   // |V|w · Slice of vertex -> index of next residual arc to try
+
+	flow = makeParallelFloats(flow, g)
+	net := newResidualNetwork(g, capacity, flow)
+
+	value := 0.0
+
+	for net.levels(level, w.makeQueue(wB), s, t) && s != t {
+		for i := range arc {
+			arc[i] = 0
+		}
+
+		for {
+			f := net.augment(level, arc, w.makeStack(wB), s, t)
+			if f == 0 {
+				break
+			}
+			value += f
+		}
+	}
+
+	// The last BFS could not reach t (unless s == t), so the vertices with
+	// a level are the source side of a minimum cut.
+	cut = resizeBitslice(cut, n)
+	for v := range level {
+		if level[v] != undefined {
+			cut.Set(v)
+		}
+	}
+
+	return value, flow, cut
+}
+
+// residualNetwork provides the arcs of the residual graph of a flow network.
+//
+// The arcs of vertex x are numbered as follows: arcs 0 through len(g[x])-1
+// correspond to the edges from x, and the rest correspond to the reversed
+// edges into x, which are indexed by in and inOffsets.
+type residualNetwork struct {
+	g              Graph
+	capacity, flow [][]float64
+	inOffsets      []int    // |V|+1 · Slice of vertex -> index of first edge in in
+	in             [][2]int // |E|   · Edges (u, i) into each vertex, grouped by head
+}
+
+func newResidualNetwork(g Graph, capacity, flow [][]float64) *residualNetwork {
+	m := 0
+	for u := range g {
+		m += len(g[u])
+	}
+
+	net := &residualNetwork{
+		g:         g,
+		capacity:  capacity,
+		flow:      flow,
+		inOffsets: make([]int, len(g)+1),
+		in:        make([][2]int, m),
+	}
+
+	// Counting sort of edges by head
+	off := net.inOffsets
+
+	for u := range g {
+		for _, v := range g[u] {
+			off[v+1]++
+		}
+	}
+	for v := 1; v < len(off); v++ {
+		off[v] += off[v-1]
+	}
+	for u := range g {
+		for i, v := range g[u] {
+			net.in[off[v]] = [2]int{u, i}
+			off[v]++
+		}
+	}
+	for v := len(off) - 1; v > 0; v-- {
+		off[v] = off[v-1]
+	}
+	off[0] = 0
+
+	return net
+}
+
+// degree returns the number of arcs of vertex x.
+func (net *residualNetwork) degree(x int) int {
+	return len(net.g[x]) + net.inOffsets[x+1] - net.inOffsets[x]
+}
+
+// residual returns the head and residual capacity of arc k of vertex x.
+func (net *residualNetwork) residual(x, k int) (y int, r float64) {
+	if k < len(net.g[x]) {
+		return net.g[x][k], net.capacity[x][k] - net.flow[x][k]
+	}
+
+	e := net.in[net.inOffsets[x]+k-len(net.g[x])]
+	u, i := e[0], e[1]
+
+	return u, net.flow[u][i]
+}
+
+// push sends f units of flow along arc k of vertex x.
+func (net *residualNetwork) push(x, k int, f float64) {
+	if k < len(net.g[x]) {
+		net.flow[x][k] += f
+		return
+	}
+
+	e := net.in[net.inOffsets[x]+k-len(net.g[x])]
+	net.flow[e[0]][e[1]] -= f
+}
+
+// levels assigns BFS levels in the residual graph from s, and returns true if
+// t is reachable. Unreachable vertices are assigned undefined.
+func (net *residualNetwork) levels(level []int, queue impl.IntQueue, s, t int) bool {
+	for i := range level {
+		level[i] = undefined
+	}
+
+	level[s] = 0
+	queue.Enqueue(s)
+
+	for queue.Len() > 0 {
+		x := queue.Dequeue()
+
+		for k, n := 0, net.degree(x); k < n; k++ {
+			if y, r := net.residual(x, k); r > 0 && level[y] == undefined {
+				level[y] = level[x] + 1
+				queue.Enqueue(y)
+			}
+		}
+	}
+
+	return level[t] != undefined
+}
+
+// augment finds a path from s to t in the level graph with an iterative DFS,
+// pushes the bottleneck flow along it, and returns the amount pushed. Arcs
+// that cannot lead to t are skipped permanently for the current phase by
+// advancing arc[x], and dead-end vertices are removed from the level graph.
+func (net *residualNetwork) augment(level, arc []int, stack impl.IntStack, s, t int) float64 {
+	stack.Push(s)
+
+	for stack.Len() > 0 {
+		x := stack.Peek()
+
+		if x == t {
+			break
+		}
+
+		advanced := false
+
+		for n := net.degree(x); arc[x] < n; arc[x]++ {
+			if y, r := net.residual(x, arc[x]); r > 0 && level[y] == level[x]+1 {
+				stack.Push(y)
+				advanced = true
+				break
+			}
+		}
+
+		if !advanced {
+			// Dead end; retreat and skip the arc into x
+			level[x] = undefined
+			stack.Pop()
+			if stack.Len() > 0 {
+				arc[stack.Peek()]++
+			}
+		}
+	}
+
+	if stack.Len() == 0 {
+		return 0
+	}
+
+	// The stack is a path from s to t, and the arc taken from each vertex
+	// on the path is arc[x], so the path can be walked again from s.
+	bottleneck := 0.0
+
+	for x := s; x != t; {
+		y, r := net.residual(x, arc[x])
+		if x == s || r < bottleneck {
+			bottleneck = r
+		}
+		x = y
+	}
+
+	for x := s; x != t; {
+		y, _ := net.residual(x, arc[x])
+		net.push(x, arc[x], bottleneck)
+		x = y
+	}
+
+	return bottleneck
+}
+
+// resizeBitslice returns a cleared bitslice with a capacity of at least nbits,
+// reusing the memory of bs if possible.
+func resizeBitslice(bs bitslice.T, nbits int) bitslice.T {
+	n := bitslice.UintLen(nbits)
+
+	if cap(bs) >= n {
+		bs = bs[:n]
+		bs.Reset()
+		return bs
+	}
+
+	return bitslice.Make(nbits)
+}
+
+// makeParallelFloats returns a zeroed [][]float64 parallel to g, backed by a
+// single slice and reusing the memory of m if possible.
+func makeParallelFloats(m [][]float64, g Graph) [][]float64 {
+	size := 0
+	for u := range g {
+		size += len(g[u])
+	}
+
+	var buf []float64
+
+	if cap(m) > 0 {
+		buf = m[:1][0]
+		buf = buf[:cap(buf)]
+	}
+
+	if len(buf) < size {
+		buf = make([]float64, size)
+	} else {
+		buf = buf[:size]
+		for i := range buf {
+			buf[i] = 0
+		}
+	}
+
+	if cap(m) >= len(g) {
+		m = m[:len(g)]
+	} else {
+		m = make([][]float64, len(g))
+	}
+
+	offset := 0
+	for u := range g {
+		m[u] = buf[offset : offset+len(g[u])]
+		offset += len(g[u])
+	}
+
+	return m
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+func TestGraphMaxFlow(t *testing.T) {
+	type E = weightedEdge
+
+	data := []struct {
+		size  int
+		edges []E
+		s, t  int
+		value float64
+		cut   []int
+	}{
+		// CLRS Figure 26.1
+		{
+			size: 6,
+			edges: []E{
+				{0, 1, 16}, {0, 2, 13},
+				{1, 3, 12},
+				{2, 1, 4}, {2, 4, 14},
+				{3, 2, 9}, {3, 5, 20},
+				{4, 3, 7}, {4, 5, 4},
+			},
+			s:     0,
+			t:     5,
+			value: 23,
+			cut:   []int{0, 1, 2, 4},
+		},
+		// Flow must be rerouted through a reverse arc
+		{
+			size: 4,
+			edges: []E{
+				{0, 1, 1}, {0, 2, 1},
+				{1, 2, 1}, {1, 3, 1},
+				{2, 3, 1},
+			},
+			s:     0,
+			t:     3,
+			value: 2,
+			cut:   []int{0},
+		},
+		// Parallel edges
+		{
+			size:  2,
+			edges: []E{{0, 1, 1}, {0, 1, 2}, {1, 0, 5}},
+			s:     0,
+			t:     1,
+			value: 3,
+			cut:   []int{0},
+		},
+		// Disconnected
+		{
+			size:  3,
+			edges: []E{{0, 1, 1}},
+			s:     0,
+			t:     2,
+			value: 0,
+			cut:   []int{0, 1},
+		},
+		// s == t
+		{
+			size:  2,
+			edges: []E{{0, 1, 1}},
+			s:     0,
+			t:     0,
+			value: 0,
+			cut:   []int{0, 1},
+		},
+	}
+
+	w := NewWorkspace(0)
+	var flow [][]float64
+	var cut bitslice.T
+
+	for _, row := range data {
+		g, capacity := makeWeightedGraph(row.size, row.edges)
+
+		var value float64
+		value, flow, cut = g.MaxFlow(flow, cut, row.s, row.t, capacity, w)
+
+		if value != row.value {
+			t.Errorf("%v != %v", value, row.value)
+		}
+
+		offsets := cut.AppendOffsets(nil)
+		if len(offsets) != len(row.cut) {
+			t.Errorf("%v != %v", offsets, row.cut)
+		} else {
+			for i := range offsets {
+				if offsets[i] != row.cut[i] {
+					t.Errorf("%v != %v", offsets, row.cut)
+					break
+				}
+			}
+		}
+
+		checkFlow(t, g, capacity, flow, cut, row.s, row.t, value)
+	}
+}
+
+func TestGraphMaxFlowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 50; trial++ {
+		size := 2 + r.Intn(12)
+		var edges []weightedEdge
+
+		for i := 0; i < size*3; i++ {
+			edges = append(edges, weightedEdge{r.Intn(size), r.Intn(size), float64(r.Intn(10))})
+		}
+
+		g, capacity := makeWeightedGraph(size, edges)
+		value, flow, cut := g.MaxFlow(nil, nil, 0, size-1, capacity, w)
+
+		checkFlow(t, g, capacity, flow, cut, 0, size-1, value)
+	}
+}
+
+// checkFlow verifies capacity constraints, flow conservation, and that the
+// capacity of the cut is equal to the value of the flow.
+func checkFlow(t *testing.T, g Graph, capacity, flow [][]float64, cut bitslice.T, s, d int, value float64) {
+	excess := make([]float64, len(g))
+	cutCapacity := 0.0
+
+	for u := range g {
+		for i, v := range g[u] {
+			if flow[u][i] < 0 || flow[u][i] > capacity[u][i] {
+				t.Errorf("flow %v of edge (%v,%v) exceeds capacity %v", flow[u][i], u, v, capacity[u][i])
+			}
+			excess[u] -= flow[u][i]
+			excess[v] += flow[u][i]
+
+			if cut.Get(u) && !cut.Get(v) {
+				cutCapacity += capacity[u][i]
+			}
+		}
+	}
+
+	for v := range excess {
+		if v != s && v != d && excess[v] != 0 {
+			t.Errorf("flow is not conserved at %v: %v", v, excess[v])
+		}
+	}
+
+	if s != d {
+		if excess[d] != value {
+			t.Errorf("%v != %v", excess[d], value)
+		}
+		if !cut.Get(s) || cut.Get(d) {
+			t.Errorf("cut %v does not separate %v and %v", cut.AppendOffsets(nil), s, d)
+		}
+		if cutCapacity != value {
+			t.Errorf("%v != %v", cutCapacity, value)
+		}
+	}
+}
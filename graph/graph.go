@@ -18,16 +18,51 @@ import (
 //
 // All edges are directed and unweighted. Undirected graphs can be constructed
 // by simply adding the reverse of each edge, and edge weights can be stored
-// in a parallel data structure.
+// in a parallel data structure; e.g. a [][]float64 where weights[u][i] is the
+// weight of the edge (u, g[u][i]).
 //
 // Because vertices are represented as signed integers, the maximum size of a
 // graph is machineUintLen/2.
 //
 type Graph [][]int
 
+// An Edge identifies a single edge (U, V) of a Graph, where V == g[U][I]. The
+// index I can be used to look up the edge in a parallel data structure; e.g.
+// weights[U][I].
+type Edge struct {
+	U, V, I int
+}
+
 // undefined is a sentinel value for the set of Vertex indices.
 const undefined = -1
 
+// Adjacency is the interface required by the algorithms of this package. It
+// is implemented by Graph and CSR, and can be implemented by any other
+// adjacency list storage, such as a memory-mapped file, so that algorithms can
+// run on it without conversion to a Graph.
+//
+// Vertices are numbered from zero to Len()-1. Neighbors returns the heads of
+// all edges from vertex u. Algorithms never modify the returned slice, and
+// never retain it across another call to Neighbors, so implementations may
+// reuse a single buffer.
+//
+// Functions that accept an Adjacency are generic over its concrete type, so
+// that passing a Graph or CSR does not allocate an interface value.
+type Adjacency interface {
+	Len() int
+	Neighbors(u int) []int
+}
+
+// Len returns the number of vertices in the graph.
+func (g Graph) Len() int {
+	return len(g)
+}
+
+// Neighbors returns the heads of all edges from vertex u.
+func (g Graph) Neighbors(u int) []int {
+	return g[u]
+}
+
 // AddEdge adds a single directed edge from vertex u to v.
 func (g Graph) AddEdge(u, v int) {
 	g[u] = append(g[u], v)
@@ -43,7 +78,12 @@ func (g Graph) AddEdge(u, v int) {
 // Note that trivial paths are not considered; i.e. there is no path from a
 // vertex u to itself except through a cycle or self-edge.
 func (g Graph) LeastEdgesPath(path []int, u, v int, w *Workspace) []int {
-	w.prepare(len(g), wA|wBNeg)
+	return LeastEdgesPath(g, path, u, v, w)
+}
+
+// LeastEdgesPath is like (Graph).LeastEdgesPath, but accepts any Adjacency.
+func LeastEdgesPath[A Adjacency](g A, path []int, u, v int, w *Workspace) []int {
+	w.prepare(g.Len(), wA|wBNeg)
 
 	dist := w.a              // |V|w · Slice of vertex -> edge distance from u
 	pred := w.b              // |V|w · Slice of vertex -> predecessor vertex
@@ -62,7 +102,7 @@ loop:
 	for queue.Len() > 0 {
 		u := queue.Dequeue()
 
-		for _, v := range g[u] {
+		for _, v := range g.Neighbors(u) {
 			if pred[v] != undefined {
 				continue
 			}
@@ -91,71 +131,132 @@ loop:
 // necessary. If a topological sort is impossible because there is a cycle in
 // the graph, an empty slice (tsort[:0]) is returned.
 func (g Graph) TopologicalSort(tsort []int, w *Workspace) []int {
-	w.prepare(len(g), 0)
+	return TopologicalSort(g, tsort, w)
+}
+
+// TopologicalSortOrCycle is like TopologicalSort, except that if a cycle is
+// discovered, the cycle is also returned as a path whose first and last
+// vertices are equal; e.g. []int{a, b, c, a}.
+//
+// The cycle is written to the cycle slice, which is grown if necessary. If
+// the topological sort succeeds, an empty slice (cycle[:0]) is returned.
+func (g Graph) TopologicalSortOrCycle(tsort, cycle []int, w *Workspace) ([]int, []int) {
+	return TopologicalSortOrCycle(g, tsort, cycle, w)
+}
+
+// TopologicalSort is like (Graph).TopologicalSort, but accepts any Adjacency.
+func TopologicalSort[A Adjacency](g A, tsort []int, w *Workspace) []int {
+	tsort, _ = topologicalSort(g, tsort, nil, false, w)
+	return tsort
+}
+
+// TopologicalSortOrCycle is like (Graph).TopologicalSortOrCycle, but accepts
+// any Adjacency.
+func TopologicalSortOrCycle[A Adjacency](g A, tsort, cycle []int, w *Workspace) ([]int, []int) {
+	return topologicalSort(g, tsort, cycle, true, w)
+}
+
+func topologicalSort[A Adjacency](g A, tsort, cycle []int, findCycle bool, w *Workspace) ([]int, []int) {
+	n := g.Len()
+	w.prepare(n, wC)
 
 	bs := w.makeBitsliceN(2, wA)
 	active := bs[0]          // |V|  · Bitslice of vertex -> active?
 	explored := bs[1]        // |V|  · Bitslice of vertex -> fully explored?
-	stack := w.makeStack(wB) // |V|w · DFS stack
+	stack := w.makeStack(wB) // |V|w · DFS stack of active vertices
+	next := w.c              // |V|w · Slice of vertex -> index of next edge to explore
 
-	tsort = resizeIntSlice(tsort, len(g)) // Prepare write buffer
-	idx := len(g)                         // tsort write index + 1
+	tsort = resizeIntSlice(tsort, n) // Prepare write buffer
+	idx := n                         // tsort write index + 1
 
-	for u := range g {
+	for u := 0; u < n; u++ {
 		if explored.Get(u) {
 			continue
 		}
 
-		// DFS
+		// DFS. Only active vertices are pushed, so the stack is always
+		// the current DFS path and never exceeds |V| elements.
+		active.Set(u)
 		stack.Push(u)
 
 		for stack.Len() > 0 {
-			u := stack.Pop()
+			u := stack.Peek()
+			edges := g.Neighbors(u)
 
 			// Post-order visit nodes whose children have been explored.
-			// These nodes are encoded as their ones' complement.
-			if u < 0 {
-				u = ^u
+			if next[u] == len(edges) {
+				stack.Pop()
 				explored.Set(u)
 				idx--
 				tsort[idx] = u
 				continue
 			}
 
-			if explored.Get(u) {
+			// Visit next child node
+			v := edges[next[u]]
+			next[u]++
+
+			if explored.Get(v) {
 				// Ignore fully explored nodes
 				continue
-			} else if active.Get(u) {
+			} else if active.Get(v) {
 				// This neighboring vertex is active but not yet
 				// fully explored, so we have discovered a cycle!
-				return tsort[:0]
+				if findCycle {
+					cycle = writeStackCycle(cycle, &stack, v)
+				}
+				return tsort[:0], cycle
 			}
 
 			// Mark this vertex as visited, but not fully explored.
-			active.Set(u)
+			active.Set(v)
+			stack.Push(v)
+		}
+	}
 
-			// Post-order visit this parent vertex after all its
-			// children have been fully explored.
-			stack.Push(^u)
+	return tsort[idx:], cycle[:0]
+}
 
-			// Visit children nodes
-			stack.PushSlice(g[u])
+// writeStackCycle writes the cycle closed by an edge to the active vertex v
+// from a DFS stack of TopologicalSort. The stack is the current DFS path, so
+// the cycle is the segment of the path from v to the top of the stack, plus
+// v again.
+func writeStackCycle(cycle []int, stack *impl.IntStack, v int) []int {
+	cycle = cycle[:0]
+
+	for {
+		u := stack.Pop()
+		cycle = append(cycle, u)
+		if u == v {
+			break
 		}
 	}
 
-	return tsort[idx:]
+	// Reverse
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+
+	return append(cycle, v)
 }
 
 // Transpose writes to h a copy of the current graph with all edges reversed.
 func (g Graph) Transpose(h Graph) Graph {
-	if cap(h) >= len(g) {
-		h = h[:len(g)]
+	return Transpose(g, h)
+}
+
+// Transpose is like (Graph).Transpose, but accepts any Adjacency.
+func Transpose[A Adjacency](g A, h Graph) Graph {
+	n := g.Len()
+
+	if cap(h) >= n {
+		h = h[:n]
 	} else {
-		h = make(Graph, len(g))
+		h = make(Graph, n)
 	}
 
-	for u := range g {
-		for _, v := range g[u] {
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
 			h.AddEdge(v, u)
 		}
 	}
@@ -174,7 +275,14 @@ func (g Graph) Transpose(h Graph) Graph {
 // Passing the same [][]int returned by this function as the scc parameter
 // reuses memory and can eliminate unnecessary allocations.
 func (g Graph) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
-	w.prepare(len(g), wANeg)
+	return StronglyConnectedComponents(g, scc, w)
+}
+
+// StronglyConnectedComponents is like (Graph).StronglyConnectedComponents,
+// but accepts any Adjacency.
+func StronglyConnectedComponents[A Adjacency](g A, scc [][]int, w *Workspace) [][]int {
+	n := g.Len()
+	w.prepare(n, wANeg)
 
 	// Tim Leslie's iterative implementation [1] of David Pearce's
 	// memory-efficient strongly connected components algorithm. [2]
@@ -186,13 +294,13 @@ func (g Graph) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
 	dfs, backtrack := w.makeSharedStacks(wB | wC) // 2|V|w · DFS/backtrack shared stack
 
 	builder := impl.NewPacked2DIntBuilderFromRows(scc)
-	builder.Grow(len(g) - builder.Cap())
+	builder.Grow(n - builder.Cap())
 	builder.SetAutoGrow(false)
 
 	i := 1
-	component := len(g) - 1
+	component := n - 1
 
-	for u := range g {
+	for u := 0; u < n; u++ {
 		if rindex[u] != undefined {
 			continue
 		}
@@ -210,7 +318,7 @@ func (g Graph) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
 				rindex[u] = i
 				i++
 
-				for _, v := range g[u] {
+				for _, v := range g.Neighbors(u) {
 					if rindex[v] == undefined {
 						dfs.pushOrPromote(v)
 					}
@@ -222,7 +330,7 @@ func (g Graph) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
 				u = dfs.pop()
 				root := true
 
-				for _, v := range g[u] {
+				for _, v := range g.Neighbors(u) {
 					if rindex[v] < rindex[u] {
 						rindex[u] = rindex[v]
 						root = false
@@ -256,6 +364,59 @@ func (g Graph) StronglyConnectedComponents(scc [][]int, w *Workspace) [][]int {
 	return builder.Rows
 }
 
+// Condensation writes to h the condensation of the graph: a directed acyclic
+// graph with one vertex per strongly connected component, and an edge (a, b)
+// whenever some edge of the graph leads from component a to component b.
+// Parallel edges and self-edges are omitted. The component index of every
+// vertex of the graph is written to comp, and the strongly connected
+// components are written to scc as with StronglyConnectedComponents. All of
+// h, comp, and scc are grown if necessary, and the results are returned.
+//
+// Components are numbered in topological order, so every edge (a, b) of the
+// condensation satisfies a < b, and iterating over its vertices in order is
+// a valid schedule for the original graph.
+func (g Graph) Condensation(h Graph, comp []int, scc [][]int, w *Workspace) (Graph, []int, [][]int) {
+	scc = g.StronglyConnectedComponents(scc, w)
+	n := len(scc)
+
+	// Components are returned in reverse topological order.
+	comp = resizeIntSlice(comp, len(g))
+	for i := range scc {
+		for _, v := range scc[i] {
+			comp[v] = n - 1 - i
+		}
+	}
+
+	if cap(h) >= n {
+		h = h[:n]
+		for i := range h {
+			h[i] = h[i][:0]
+		}
+	} else {
+		h = make(Graph, n)
+	}
+
+	last := w.a[:n] // |C|w · Slice of component -> last component with an edge to it
+	for i := range last {
+		last[i] = undefined
+	}
+
+	for i := range scc {
+		a := n - 1 - i
+
+		for _, u := range scc[i] {
+			for _, v := range g[u] {
+				if b := comp[v]; b != a && last[b] != a {
+					last[b] = a
+					h.AddEdge(a, b)
+				}
+			}
+		}
+	}
+
+	return h, comp, scc
+}
+
 func writePath(path, pred []int, v int, pathLen int) []int {
 	path = resizeIntSlice(path, pathLen+1)
 	path[pathLen] = v
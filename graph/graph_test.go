@@ -136,6 +136,23 @@ func TestGraphLeastEdgesPath(t *testing.T) {
 	}
 }
 
+func TestGraphLeastEdgesPathAllocs(t *testing.T) {
+	g := Graph{{1, 3}, {2}, {3}, {0}}
+	c := NewCSR(g)
+
+	w := NewWorkspace(len(g))
+	path := make([]int, len(g))
+
+	for _, f := range []func(){
+		func() { path = g.LeastEdgesPath(path, 0, 2, w) },
+		func() { path = c.LeastEdgesPath(path, 0, 2, w) },
+	} {
+		if allocs := testing.AllocsPerRun(10, f); allocs != 0 {
+			t.Errorf("%v != %v", allocs, 0)
+		}
+	}
+}
+
 func TestGraphTopologicalSort(t *testing.T) {
 	type Adj = map[int][]int
 
@@ -387,3 +404,225 @@ func TestStronglyConnectedComponents(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphCondensation(t *testing.T) {
+	data := []struct {
+		size int
+		adj  map[int][]int
+		h    Graph
+		comp []int
+	}{
+		{
+			size: 0,
+			adj:  map[int][]int{},
+			h:    nil,
+			comp: nil,
+		},
+		{
+			size: 8,
+			adj: map[int][]int{
+				0: {4},
+				1: {0},
+				2: {1, 3},
+				3: {2},
+				4: {1},
+				5: {1, 4, 6},
+				6: {2, 5},
+				7: {3, 6, 7},
+			},
+			h: Graph{
+				0: {1, 2},
+				1: {2, 3},
+				2: {3},
+				3: nil,
+			},
+			comp: []int{3, 3, 2, 2, 3, 1, 1, 0},
+		},
+		// DAG
+		{
+			size: 3,
+			adj: map[int][]int{
+				0: {1, 2},
+				1: {2, 2},
+			},
+			h: Graph{
+				0: {1, 2},
+				1: {2},
+				2: nil,
+			},
+			comp: []int{0, 1, 2},
+		},
+	}
+
+	w := NewWorkspace(0)
+	var h Graph
+	var comp []int
+	var scc [][]int
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+
+		for u, edges := range row.adj {
+			for _, v := range edges {
+				g.AddEdge(u, v)
+			}
+		}
+
+		h, comp, scc = g.Condensation(h, comp, scc, w)
+
+		if s := g.StronglyConnectedComponents(nil, w); !reflect.DeepEqual(scc, s) {
+			t.Errorf("%v != %v", scc, s)
+		}
+
+		for i := range h {
+			impl.QuicksortIntSlice(h[i])
+			if len(h[i]) == 0 {
+				h[i] = nil
+			}
+		}
+
+		if !reflect.DeepEqual(h, row.h) {
+			t.Errorf("%v != %v", h, row.h)
+		}
+		if !reflect.DeepEqual(comp, row.comp) {
+			t.Errorf("%v != %v", comp, row.comp)
+		}
+	}
+}
+
+func TestGraphTopologicalSortOrCycle(t *testing.T) {
+	data := []struct {
+		size  int
+		adj   map[int][]int
+		tsort []int
+		cycle []int
+	}{
+		{
+			size:  3,
+			adj:   map[int][]int{0: {1}, 1: {2}},
+			tsort: []int{0, 1, 2},
+			cycle: []int{},
+		},
+		{
+			size:  4,
+			adj:   map[int][]int{0: {1}, 1: {2}, 2: {3}, 3: {1}},
+			tsort: []int{},
+			cycle: []int{1, 2, 3, 1},
+		},
+		// Self-loop
+		{
+			size:  4,
+			adj:   map[int][]int{0: {1}, 1: {2}, 2: {2, 3}},
+			tsort: []int{},
+			cycle: []int{2, 2},
+		},
+		// Cycle discovered after other branches
+		{
+			size: 6,
+			adj: map[int][]int{
+				0: {1, 2},
+				1: {3},
+				2: {3, 4},
+				4: {5},
+				5: {2},
+			},
+			tsort: []int{},
+			cycle: []int{2, 4, 5, 2},
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+
+		for u, vs := range row.adj {
+			for _, v := range vs {
+				g.AddEdge(u, v)
+			}
+		}
+
+		tsort, cycle := g.TopologicalSortOrCycle([]int{}, []int{}, w)
+
+		if !reflect.DeepEqual(tsort, row.tsort) {
+			t.Errorf("%v != %v", tsort, row.tsort)
+		}
+		if !isRotation(cycle, row.cycle) {
+			t.Errorf("%v is not a rotation of %v", cycle, row.cycle)
+		}
+
+		// Every pair of consecutive vertices must be an edge
+		for i := 0; i+1 < len(cycle); i++ {
+			found := false
+			for _, v := range g[cycle[i]] {
+				if v == cycle[i+1] {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("(%v,%v) is not an edge in cycle %v", cycle[i], cycle[i+1], cycle)
+			}
+		}
+	}
+}
+
+// gridAdjacency is an implicit Adjacency of a w×h grid with edges to the
+// right and downward neighbors of every cell. Neighbors reuses a buffer.
+type gridAdjacency struct {
+	w, h int
+	buf  []int
+}
+
+func (g *gridAdjacency) Len() int {
+	return g.w * g.h
+}
+
+func (g *gridAdjacency) Neighbors(u int) []int {
+	g.buf = g.buf[:0]
+	if (u+1)%g.w != 0 {
+		g.buf = append(g.buf, u+1)
+	}
+	if u+g.w < g.Len() {
+		g.buf = append(g.buf, u+g.w)
+	}
+	return g.buf
+}
+
+func TestAdjacency(t *testing.T) {
+	a := &gridAdjacency{w: 4, h: 3}
+	w := NewWorkspace(0)
+
+	// Materialize the grid as a Graph to compare results
+	g := make(Graph, a.Len())
+	for u := range g {
+		for _, v := range a.Neighbors(u) {
+			g.AddEdge(u, v)
+		}
+	}
+
+	if path := LeastEdgesPath(a, nil, 0, 11, w); len(path) != 6 || path[0] != 0 || path[5] != 11 {
+		t.Errorf("invalid path: %v", path)
+	}
+	if path := LeastEdgesPath(a, nil, 11, 0, w); len(path) != 0 {
+		t.Errorf("%v != %v", path, []int{})
+	}
+
+	tsort := TopologicalSort(a, nil, w)
+	if !reflect.DeepEqual(tsort, g.TopologicalSort(nil, w)) {
+		t.Errorf("%v != %v", tsort, g.TopologicalSort(nil, w))
+	}
+
+	tsort, cycle := TopologicalSortOrCycle(a, nil, nil, w)
+	if len(tsort) != a.Len() || len(cycle) != 0 {
+		t.Errorf("tsort: %v, cycle: %v", tsort, cycle)
+	}
+
+	scc := StronglyConnectedComponents(a, nil, w)
+	if !reflect.DeepEqual(scc, g.StronglyConnectedComponents(nil, w)) {
+		t.Errorf("%v != %v", scc, g.StronglyConnectedComponents(nil, w))
+	}
+
+	if h := Transpose(a, nil); !reflect.DeepEqual(h, g.Transpose(nil)) {
+		t.Errorf("%v != %v", h, g.Transpose(nil))
+	}
+}
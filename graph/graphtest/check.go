@@ -0,0 +1,209 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graphtest
+
+import (
+	"fmt"
+
+	"github.com/guns/golibs/graph"
+)
+
+// The following functions check the results of graph algorithms against
+// their definitions with simple, slow reference implementations. Each returns
+// nil if the result is valid, or an error that describes the first problem
+// found.
+
+// CheckPath checks that path is a path from vertex u to v in g; i.e. a walk
+// of at least one edge that starts at u and ends at v.
+func CheckPath(g graph.Adjacency, path []int, u, v int) error {
+	if len(path) < 2 {
+		return fmt.Errorf("path %v has no edges", path)
+	}
+	if path[0] != u || path[len(path)-1] != v {
+		return fmt.Errorf("path %v does not lead from %d to %d", path, u, v)
+	}
+
+	for i := 0; i+1 < len(path); i++ {
+		if !hasEdge(g, path[i], path[i+1]) {
+			return fmt.Errorf("path %v: (%d, %d) is not an edge", path, path[i], path[i+1])
+		}
+	}
+
+	return nil
+}
+
+// CheckCycle checks that cycle is a closed walk in g, with the first vertex
+// repeated at the end; e.g. []int{a, b, c, a}.
+func CheckCycle(g graph.Adjacency, cycle []int) error {
+	if len(cycle) == 0 {
+		return fmt.Errorf("empty cycle")
+	}
+
+	return CheckPath(g, cycle, cycle[0], cycle[0])
+}
+
+// CheckTopologicalSort checks that tsort contains every vertex of g exactly
+// once, and that every edge of g leads from an earlier vertex to a later one.
+func CheckTopologicalSort(g graph.Adjacency, tsort []int) error {
+	n := g.Len()
+
+	if len(tsort) != n {
+		return fmt.Errorf("topological sort has %d vertices, not %d", len(tsort), n)
+	}
+
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+
+	for i, v := range tsort {
+		if v < 0 || v >= n || pos[v] != -1 {
+			return fmt.Errorf("topological sort %v is not a permutation", tsort)
+		}
+		pos[v] = i
+	}
+
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
+			if pos[u] >= pos[v] {
+				return fmt.Errorf("edge (%d, %d) is out of topological order", u, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckStronglyConnectedComponents checks that scc is a partition of the
+// vertices of g, and that two vertices are in the same component if and only
+// if each is reachable from the other. The order of components is not
+// checked.
+func CheckStronglyConnectedComponents(g graph.Adjacency, scc [][]int) error {
+	n := g.Len()
+	comp, err := partition(n, scc)
+	if err != nil {
+		return err
+	}
+
+	reach := Reachability(g)
+
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			strong := u == v || reach[u][v] && reach[v][u]
+			if strong != (comp[u] == comp[v]) {
+				return fmt.Errorf("vertices %d and %d: strongly connected = %v, same component = %v",
+					u, v, strong, comp[u] == comp[v])
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckWeaklyConnectedComponents is like CheckStronglyConnectedComponents,
+// except that vertices must be in the same component if and only if they are
+// connected when edge directions are ignored.
+func CheckWeaklyConnectedComponents(g graph.Adjacency, wcc [][]int) error {
+	n := g.Len()
+	comp, err := partition(n, wcc)
+	if err != nil {
+		return err
+	}
+
+	// Symmetric copy
+	h := make(graph.Graph, n)
+	for u := 0; u < n; u++ {
+		for _, v := range g.Neighbors(u) {
+			h.AddEdge(u, v)
+			h.AddEdge(v, u)
+		}
+	}
+
+	reach := Reachability(h)
+
+	for u := 0; u < n; u++ {
+		for v := 0; v < n; v++ {
+			weak := u == v || reach[u][v]
+			if weak != (comp[u] == comp[v]) {
+				return fmt.Errorf("vertices %d and %d: weakly connected = %v, same component = %v",
+					u, v, weak, comp[u] == comp[v])
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reachability returns a matrix where m[u][v] is true if there is a path of
+// at least one edge from vertex u to v.
+func Reachability(g graph.Adjacency) [][]bool {
+	n := g.Len()
+	m := make([][]bool, n)
+
+	for s := 0; s < n; s++ {
+		m[s] = make([]bool, n)
+		stack := []int{s}
+
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			for _, v := range g.Neighbors(u) {
+				if !m[s][v] {
+					m[s][v] = true
+					stack = append(stack, v)
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+// partition returns the index of the group of each vertex, or an error if
+// groups is not a partition of the n vertices.
+func partition(n int, groups [][]int) ([]int, error) {
+	comp := make([]int, n)
+	for i := range comp {
+		comp[i] = -1
+	}
+
+	for i, group := range groups {
+		if len(group) == 0 {
+			return nil, fmt.Errorf("component %d is empty", i)
+		}
+		for _, v := range group {
+			if v < 0 || v >= n {
+				return nil, fmt.Errorf("component %d: invalid vertex %d", i, v)
+			}
+			if comp[v] != -1 {
+				return nil, fmt.Errorf("vertex %d is in components %d and %d", v, comp[v], i)
+			}
+			comp[v] = i
+		}
+	}
+
+	for v, c := range comp {
+		if c == -1 {
+			return nil, fmt.Errorf("vertex %d is not in any component", v)
+		}
+	}
+
+	return comp, nil
+}
+
+func hasEdge(g graph.Adjacency, u, v int) bool {
+	if u < 0 || u >= g.Len() {
+		return false
+	}
+
+	for _, x := range g.Neighbors(u) {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
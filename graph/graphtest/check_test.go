@@ -0,0 +1,159 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graphtest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/guns/golibs/graph"
+)
+
+func makeGraph(size int, edges [][2]int) graph.Graph {
+	g := make(graph.Graph, size)
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestCheckPath(t *testing.T) {
+	g := makeGraph(4, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}})
+
+	data := []struct {
+		path  []int
+		u, v  int
+		valid bool
+	}{
+		{path: nil, u: 0, v: 0, valid: false},
+		{path: []int{0}, u: 0, v: 0, valid: false},
+		{path: []int{0, 1}, u: 0, v: 1, valid: true},
+		{path: []int{0, 1, 2, 3}, u: 0, v: 3, valid: true},
+		{path: []int{0, 1, 2, 3}, u: 1, v: 3, valid: false},
+		{path: []int{0, 1, 2, 3}, u: 0, v: 2, valid: false},
+		{path: []int{0, 2, 3}, u: 0, v: 3, valid: false},
+		{path: []int{0, 1, 2, 0}, u: 0, v: 0, valid: true},
+		{path: []int{0, 1, 5}, u: 0, v: 5, valid: false},
+	}
+
+	for _, row := range data {
+		err := CheckPath(g, row.path, row.u, row.v)
+		if (err == nil) != row.valid {
+			t.Errorf("%v %d %d: %v", row.path, row.u, row.v, err)
+		}
+	}
+
+	if err := CheckCycle(g, []int{1, 2, 0, 1}); err != nil {
+		t.Errorf("%v != %v", err, nil)
+	}
+	if err := CheckCycle(g, []int{1, 2, 3}); err == nil {
+		t.Errorf("%v == %v", err, nil)
+	}
+}
+
+func TestCheckTopologicalSort(t *testing.T) {
+	g := makeGraph(4, [][2]int{{2, 0}, {0, 1}, {2, 3}})
+
+	data := []struct {
+		tsort []int
+		valid bool
+	}{
+		{tsort: []int{2, 0, 1, 3}, valid: true},
+		{tsort: []int{2, 3, 0, 1}, valid: true},
+		{tsort: []int{0, 2, 1, 3}, valid: false},
+		{tsort: []int{2, 0, 1}, valid: false},
+		{tsort: []int{2, 0, 0, 1}, valid: false},
+		{tsort: []int{2, 0, 1, 4}, valid: false},
+	}
+
+	for _, row := range data {
+		err := CheckTopologicalSort(g, row.tsort)
+		if (err == nil) != row.valid {
+			t.Errorf("%v: %v", row.tsort, err)
+		}
+	}
+}
+
+func TestCheckComponents(t *testing.T) {
+	g := makeGraph(5, [][2]int{{0, 1}, {1, 0}, {1, 2}, {3, 4}})
+
+	data := []struct {
+		components [][]int
+		strong     bool
+		weak       bool
+	}{
+		{components: [][]int{{0, 1}, {2}, {3}, {4}}, strong: true, weak: false},
+		{components: [][]int{{4}, {3}, {2}, {1, 0}}, strong: true, weak: false},
+		{components: [][]int{{0, 1, 2}, {3, 4}}, strong: false, weak: true},
+		{components: [][]int{{0}, {1}, {2}, {3}, {4}}, strong: false, weak: false},
+		{components: [][]int{{0, 1}, {2}, {3}}, strong: false, weak: false},
+		{components: [][]int{{0, 1}, {1, 2}, {3}, {4}}, strong: false, weak: false},
+		{components: [][]int{{0, 1}, {}, {2}, {3}, {4}}, strong: false, weak: false},
+	}
+
+	for _, row := range data {
+		err := CheckStronglyConnectedComponents(g, row.components)
+		if (err == nil) != row.strong {
+			t.Errorf("%v: %v", row.components, err)
+		}
+		err = CheckWeaklyConnectedComponents(g, row.components)
+		if (err == nil) != row.weak {
+			t.Errorf("%v: %v", row.components, err)
+		}
+	}
+}
+
+// Fuzz the algorithms of package graph with random graphs
+func TestRandomGraphs(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := graph.NewWorkspace(0)
+
+	var tsort, path []int
+	var scc, wcc [][]int
+
+	for i := 0; i < 100; i++ {
+		n := r.Intn(40)
+
+		dag := RandomDAG(r, n, r.Float64()*0.3)
+		tsort = dag.TopologicalSort(tsort, w)
+		if err := CheckTopologicalSort(dag, tsort); err != nil {
+			t.Errorf("%v: %v", dag, err)
+		}
+
+		g := ErdosRenyi(r, n, r.Float64()*0.1)
+
+		scc = g.StronglyConnectedComponents(scc, w)
+		if err := CheckStronglyConnectedComponents(g, scc); err != nil {
+			t.Errorf("%v: %v", g, err)
+		}
+
+		wcc = g.WeaklyConnectedComponents(wcc, w)
+		if err := CheckWeaklyConnectedComponents(g, wcc); err != nil {
+			t.Errorf("%v: %v", g, err)
+		}
+
+		if _, cycle := g.TopologicalSortOrCycle(nil, nil, w); len(cycle) > 0 {
+			if err := CheckCycle(g, cycle); err != nil {
+				t.Errorf("%v: %v", g, err)
+			}
+		}
+
+		if n == 0 {
+			continue
+		}
+
+		reach := Reachability(g)
+		u, v := r.Intn(n), r.Intn(n)
+
+		path = g.LeastEdgesPath(path, u, v, w)
+		if reach[u][v] != (len(path) > 0) {
+			t.Errorf("%v: reachable %d -> %d = %v, path = %v", g, u, v, reach[u][v], path)
+		} else if len(path) > 0 {
+			if err := CheckPath(g, path, u, v); err != nil {
+				t.Errorf("%v: %v", g, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+// Package graphtest provides random graph generators and checkers of the
+// results of graph algorithms, for testing code built on package graph.
+//
+// All generators draw from a caller-provided *rand.Rand, so results are
+// reproducible from a seed.
+package graphtest
+
+import (
+	"math/rand"
+
+	"github.com/guns/golibs/bitslice"
+	"github.com/guns/golibs/graph"
+)
+
+// ErdosRenyi returns a directed G(n, p) random graph: each of the n(n-1)
+// possible edges (u, v) with u != v is present with probability p.
+func ErdosRenyi(r *rand.Rand, n int, p float64) graph.Graph {
+	g := make(graph.Graph, n)
+
+	for u := range g {
+		for v := 0; v < n; v++ {
+			if u != v && r.Float64() < p {
+				g.AddEdge(u, v)
+			}
+		}
+	}
+
+	return g
+}
+
+// RandomDAG returns a random directed acyclic graph. Each edge of a random
+// topological order of n vertices is present with probability p, so the
+// identity permutation is generally not a topological sort of the result.
+func RandomDAG(r *rand.Rand, n int, p float64) graph.Graph {
+	g := make(graph.Graph, n)
+	order := r.Perm(n)
+
+	for i := range order {
+		for j := i + 1; j < n; j++ {
+			if r.Float64() < p {
+				g.AddEdge(order[i], order[j])
+			}
+		}
+	}
+
+	return g
+}
+
+// Grid returns an undirected w×h grid graph, where each vertex is connected
+// to its horizontal and vertical neighbors. Vertex (x, y) has the index
+// y*w + x.
+func Grid(w, h int) graph.Graph {
+	g := make(graph.Graph, w*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			u := y*w + x
+			if x+1 < w {
+				addUndirectedEdge(g, u, u+1)
+			}
+			if y+1 < h {
+				addUndirectedEdge(g, u, u+w)
+			}
+		}
+	}
+
+	return g
+}
+
+// PreferentialAttachment returns an undirected scale-free graph of n vertices
+// generated by the Barabási–Albert model: every vertex after the first m is
+// connected to m distinct earlier vertices, which are chosen with probability
+// proportional to their degree.
+func PreferentialAttachment(r *rand.Rand, n, m int) graph.Graph {
+	g := make(graph.Graph, n)
+
+	// Every endpoint of every edge, so a uniform choice from this slice is
+	// proportional to degree.
+	var ends []int
+	chosen := make(map[int]bool, m)
+
+	for v := 0; v < n; v++ {
+		if v <= m {
+			// Seed with a complete graph
+			for u := 0; u < v; u++ {
+				addUndirectedEdge(g, u, v)
+				ends = append(ends, u, v)
+			}
+			continue
+		}
+
+		for u := range chosen {
+			delete(chosen, u)
+		}
+
+		for len(chosen) < m {
+			chosen[ends[r.Intn(len(ends))]] = true
+		}
+
+		// Map iteration order is random, so add edges in ascending order
+		// for reproducibility.
+		for u := 0; u < v; u++ {
+			if chosen[u] {
+				addUndirectedEdge(g, u, v)
+				ends = append(ends, u, v)
+			}
+		}
+	}
+
+	return g
+}
+
+// CompleteBipartite returns an undirected complete bipartite graph K(a, b).
+// Vertices [0, a) are in the left set, which is also returned as a bitslice,
+// and vertices [a, a+b) are in the right set.
+func CompleteBipartite(a, b int) (graph.Graph, bitslice.T) {
+	g := make(graph.Graph, a+b)
+	left := bitslice.Make(a + b)
+
+	for u := 0; u < a; u++ {
+		left.Set(u)
+		for v := a; v < a+b; v++ {
+			addUndirectedEdge(g, u, v)
+		}
+	}
+
+	return g, left
+}
+
+func addUndirectedEdge(g graph.Graph, u, v int) {
+	g.AddEdge(u, v)
+	g.AddEdge(v, u)
+}
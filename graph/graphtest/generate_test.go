@@ -0,0 +1,193 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graphtest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/graph"
+)
+
+func countEdges(g graph.Graph) int {
+	n := 0
+	for u := range g {
+		n += len(g[u])
+	}
+	return n
+}
+
+func isSymmetric(g graph.Graph) bool {
+	for u := range g {
+		for _, v := range g[u] {
+			if !g.HasEdge(v, u) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestErdosRenyi(t *testing.T) {
+	data := []struct {
+		n     int
+		p     float64
+		edges int
+	}{
+		{n: 0, p: 0.5, edges: 0},
+		{n: 10, p: 0, edges: 0},
+		{n: 10, p: 1, edges: 90},
+	}
+
+	for _, row := range data {
+		g := ErdosRenyi(rand.New(rand.NewSource(1)), row.n, row.p)
+		if len(g) != row.n {
+			t.Errorf("%v != %v", len(g), row.n)
+		}
+		if countEdges(g) != row.edges {
+			t.Errorf("%v != %v", countEdges(g), row.edges)
+		}
+		for u := range g {
+			if g.HasEdge(u, u) {
+				t.Errorf("self edge (%d, %d)", u, u)
+			}
+		}
+	}
+
+	// Seeded generators are reproducible
+	g := ErdosRenyi(rand.New(rand.NewSource(42)), 50, 0.1)
+	h := ErdosRenyi(rand.New(rand.NewSource(42)), 50, 0.1)
+	if !reflect.DeepEqual(g, h) {
+		t.Errorf("%v != %v", g, h)
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20; i++ {
+		g := RandomDAG(r, r.Intn(50), 0.2)
+		if _, cycle := g.TopologicalSortOrCycle(nil, nil, graph.NewWorkspace(0)); len(cycle) > 0 {
+			t.Errorf("cycle %v in DAG %v", cycle, g)
+		}
+	}
+
+	g := RandomDAG(r, 10, 1)
+	if countEdges(g) != 45 {
+		t.Errorf("%v != %v", countEdges(g), 45)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	data := []struct {
+		w, h  int
+		edges int
+	}{
+		{w: 0, h: 0, edges: 0},
+		{w: 1, h: 1, edges: 0},
+		{w: 3, h: 1, edges: 4},
+		{w: 3, h: 2, edges: 14},
+		{w: 4, h: 4, edges: 48},
+	}
+
+	for _, row := range data {
+		g := Grid(row.w, row.h)
+		if len(g) != row.w*row.h {
+			t.Errorf("%v != %v", len(g), row.w*row.h)
+		}
+		if countEdges(g) != row.edges {
+			t.Errorf("%v != %v", countEdges(g), row.edges)
+		}
+		if !isSymmetric(g) {
+			t.Errorf("asymmetric grid %v", g)
+		}
+	}
+
+	g := Grid(3, 2)
+	if !reflect.DeepEqual(g[4], []int{1, 3, 5}) {
+		t.Errorf("%v != %v", g[4], []int{1, 3, 5})
+	}
+}
+
+func TestPreferentialAttachment(t *testing.T) {
+	data := []struct {
+		n, m int
+	}{
+		{n: 0, m: 2},
+		{n: 1, m: 2},
+		{n: 3, m: 2},
+		{n: 100, m: 1},
+		{n: 100, m: 3},
+	}
+
+	for _, row := range data {
+		g := PreferentialAttachment(rand.New(rand.NewSource(1)), row.n, row.m)
+		if len(g) != row.n {
+			t.Errorf("%v != %v", len(g), row.n)
+		}
+		if !isSymmetric(g) {
+			t.Errorf("asymmetric graph %v", g)
+		}
+
+		// Complete seed of m+1 vertices, then m edges for every other vertex
+		edges := 0
+		if row.n > row.m {
+			edges = row.m*(row.m+1) + 2*row.m*(row.n-row.m-1)
+		} else if row.n > 0 {
+			edges = row.n * (row.n - 1)
+		}
+		if countEdges(g) != edges {
+			t.Errorf("%v != %v", countEdges(g), edges)
+		}
+
+		removed := g.RemoveParallelEdges(graph.NewWorkspace(0))
+		if removed != 0 {
+			t.Errorf("%v != %v", removed, 0)
+		}
+
+		if wcc := g.WeaklyConnectedComponents(nil, graph.NewWorkspace(0)); row.n > 0 && len(wcc) != 1 {
+			t.Errorf("%v != %v", len(wcc), 1)
+		}
+	}
+}
+
+func TestCompleteBipartite(t *testing.T) {
+	data := []struct {
+		a, b int
+	}{
+		{a: 0, b: 0},
+		{a: 0, b: 3},
+		{a: 2, b: 3},
+		{a: 5, b: 5},
+	}
+
+	for _, row := range data {
+		g, left := CompleteBipartite(row.a, row.b)
+		if len(g) != row.a+row.b {
+			t.Errorf("%v != %v", len(g), row.a+row.b)
+		}
+		if countEdges(g) != 2*row.a*row.b {
+			t.Errorf("%v != %v", countEdges(g), 2*row.a*row.b)
+		}
+		for u := range g {
+			for _, v := range g[u] {
+				if left.Get(u) == left.Get(v) {
+					t.Errorf("edge (%d, %d) within a partition", u, v)
+				}
+			}
+		}
+
+		min := row.a
+		if row.b < min {
+			min = row.b
+		}
+
+		_, size := g.BipartiteMatching(nil, left, graph.NewWorkspace(0))
+		if size != min {
+			t.Errorf("%v != %v", size, min)
+		}
+	}
+}
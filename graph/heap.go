@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+// indexedHeap is a binary min-heap of vertex indices ordered by a parallel
+// slice of keys. The heap position of every vertex is tracked, so the key of
+// a vertex that is already on the heap can be decreased in O(log n) time.
+//
+// The key slice is owned by the caller: to insert a vertex or decrease its
+// key, first write the new key to key[v], then call update(v).
+type indexedHeap[K heapKey] struct {
+	a   []int // Heap-ordered vertex indices
+	pos []int // Vertex -> index in a, or undefined if not on the heap
+	key []K   // Vertex -> key
+	len int
+}
+
+// heapKey is the set of key types of an indexedHeap.
+type heapKey interface {
+	int | float64
+}
+
+// newIndexedHeap returns an empty indexedHeap. All values of pos must be
+// undefined, and len(a), len(pos), and len(key) must be at least the number
+// of vertices that will be pushed.
+func newIndexedHeap[K heapKey](a, pos []int, key []K) *indexedHeap[K] {
+	return &indexedHeap[K]{
+		a:   a,
+		pos: pos,
+		key: key,
+		len: 0,
+	}
+}
+
+// update pushes vertex v onto the heap if it is absent, otherwise it restores
+// the heap property after key[v] has been decreased.
+func (h *indexedHeap[K]) update(v int) {
+	i := h.pos[v]
+
+	if i == undefined {
+		i = h.len
+		h.a[i] = v
+		h.pos[v] = i
+		h.len++
+	}
+
+	h.up(i)
+}
+
+// pop removes and returns the vertex with the minimum key. Calling pop on an
+// empty heap results in a panic.
+func (h *indexedHeap[K]) pop() int {
+	v := h.a[0]
+	h.len--
+	h.pos[v] = undefined
+
+	if h.len > 0 {
+		last := h.a[h.len]
+		h.a[0] = last
+		h.pos[last] = 0
+		h.down(0)
+	}
+
+	return v
+}
+
+func (h *indexedHeap[K]) up(i int) {
+	v := h.a[i]
+	k := h.key[v]
+
+	for i > 0 {
+		parent := (i - 1) / 2
+		p := h.a[parent]
+
+		if h.key[p] <= k {
+			break
+		}
+
+		h.a[i] = p
+		h.pos[p] = i
+		i = parent
+	}
+
+	h.a[i] = v
+	h.pos[v] = i
+}
+
+func (h *indexedHeap[K]) down(i int) {
+	v := h.a[i]
+	k := h.key[v]
+
+	for {
+		child := 2*i + 1
+		if child >= h.len {
+			break
+		}
+
+		if right := child + 1; right < h.len && h.key[h.a[right]] < h.key[h.a[child]] {
+			child = right
+		}
+
+		c := h.a[child]
+		if k <= h.key[c] {
+			break
+		}
+
+		h.a[i] = c
+		h.pos[c] = i
+		i = child
+	}
+
+	h.a[i] = v
+	h.pos[v] = i
+}
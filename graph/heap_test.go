@@ -0,0 +1,73 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	const size = 64
+
+	key := make([]float64, size)
+	pos := make([]int, size)
+	for i := range pos {
+		pos[i] = undefined
+	}
+
+	h := newIndexedHeap(make([]int, size), pos, key)
+	r := rand.New(rand.NewSource(1))
+
+	for v := range key {
+		key[v] = float64(r.Intn(1000))
+		h.update(v)
+	}
+
+	// Decrease the keys of every other vertex
+	for v := 0; v < size; v += 2 {
+		key[v] -= float64(r.Intn(1000))
+		h.update(v)
+	}
+
+	if h.len != size {
+		t.Errorf("%v != %v", h.len, size)
+	}
+
+	seen := make([]bool, size)
+	prev := key[h.a[0]]
+
+	for h.len > 0 {
+		v := h.pop()
+
+		if key[v] < prev {
+			t.Errorf("key[%v] %v < previous key %v", v, key[v], prev)
+		}
+		if pos[v] != undefined {
+			t.Errorf("%v != %v", pos[v], undefined)
+		}
+
+		prev = key[v]
+		seen[v] = true
+	}
+
+	for v := range seen {
+		if !seen[v] {
+			t.Errorf("vertex %v was never popped", v)
+		}
+	}
+
+	// The heap is reusable once empty
+	key[3], key[1], key[2] = 3, 1, 2
+	h.update(3)
+	h.update(1)
+	h.update(2)
+
+	out := []int{h.pop(), h.pop(), h.pop()}
+	if !reflect.DeepEqual(out, []int{1, 2, 3}) {
+		t.Errorf("%v != %v", out, []int{1, 2, 3})
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import "github.com/guns/golibs/generic/impl"
+
+// IncrementalSCC maintains the strongly connected components of a Graph as
+// edges are added one at a time.
+//
+// Components are identified by a representative vertex. A topological order
+// of the component graph is maintained with the dynamic topological sort of
+// Pearce and Kelly [1], so adding an edge that agrees with the current order
+// takes constant time, and any other edge only visits the components whose
+// order lies between the edge's endpoints. Components merged by a new edge
+// are joined with a union-find forest.
+//
+// [1]: http://homepages.ecs.vuw.ac.nz/~djp/files/PK-JEA07.pdf
+type IncrementalSCC struct {
+	g, rev Graph
+	parent []int // Union-find forest of vertex -> parent vertex
+	size   []int // Representative -> number of member vertices
+	next   []int // Circular linked lists of component members
+	ord    []int // Representative -> topological index
+	at     []int // Topological index -> representative, or undefined
+	mark   []int // Representative -> last search epoch
+	epoch  int
+	len    int // Number of components
+
+	// Search space shared by forward and backward searches
+	dfs             autoPromotingStack
+	done            nonPromotingStack
+	fwd, bwd, slots []int
+}
+
+// NewIncrementalSCC returns an IncrementalSCC seeded with the strongly
+// connected components of g. Edges added through the returned value are
+// also added to g, so g should not be modified elsewhere afterwards.
+//
+// The initial components of g are written to scc and returned as with
+// (Graph).StronglyConnectedComponents. Passing the returned components as the
+// scc parameter reuses memory.
+func NewIncrementalSCC(g Graph, scc [][]int, w *Workspace) (*IncrementalSCC, [][]int) {
+	n := len(g)
+	buf := make([]int, n*9)
+
+	s := &IncrementalSCC{
+		g:      g,
+		rev:    g.Transpose(nil),
+		parent: buf[:n],
+		size:   buf[n : n*2],
+		next:   buf[n*2 : n*3],
+		ord:    buf[n*3 : n*4],
+		at:     buf[n*4 : n*5],
+		mark:   buf[n*5 : n*6],
+		fwd:    buf[n*6 : n*6 : n*7],
+		bwd:    buf[n*7 : n*7 : n*8],
+		slots:  buf[n*8 : n*8 : n*9],
+	}
+
+	stackbuf := make([]int, n*2)
+	for i := range stackbuf {
+		stackbuf[i] = undefined
+	}
+	s.dfs = *newAutoPromotingStack(stackbuf)
+	s.done = *newNonPromotingStack(stackbuf)
+
+	for i := range s.at {
+		s.at[i] = undefined
+	}
+
+	// Components are returned in reverse topological order.
+	scc = g.StronglyConnectedComponents(scc, w)
+	s.len = len(scc)
+
+	for i := range scc {
+		r := scc[i][0]
+		prev := r
+
+		for _, v := range scc[i] {
+			s.parent[v] = r
+			s.next[prev] = v
+			prev = v
+		}
+
+		s.next[prev] = r
+		s.size[r] = len(scc[i])
+		s.ord[r] = len(scc) - 1 - i
+		s.at[s.ord[r]] = r
+	}
+
+	return s, scc
+}
+
+// Graph returns the underlying graph.
+func (s *IncrementalSCC) Graph() Graph {
+	return s.g
+}
+
+// Len returns the current number of strongly connected components.
+func (s *IncrementalSCC) Len() int {
+	return s.len
+}
+
+// Component returns the representative vertex of the strongly connected
+// component that contains vertex v. Two vertices are strongly connected iff
+// their representatives are equal. Note that the representative of a
+// component may change when it is merged with another.
+func (s *IncrementalSCC) Component(v int) int {
+	// Path halving
+	for s.parent[v] != v {
+		s.parent[v] = s.parent[s.parent[v]]
+		v = s.parent[v]
+	}
+
+	return v
+}
+
+// Order returns the position of the component that contains vertex v in a
+// topological order of the component graph. If there is a path from u to v,
+// then Order(u) <= Order(v).
+func (s *IncrementalSCC) Order(v int) int {
+	return s.ord[s.Component(v)]
+}
+
+// Members appends the vertices of the component that contains vertex v to
+// dst and returns the result.
+func (s *IncrementalSCC) Members(dst []int, v int) []int {
+	r := s.Component(v)
+	x := r
+
+	for {
+		dst = append(dst, x)
+		x = s.next[x]
+		if x == r {
+			return dst
+		}
+	}
+}
+
+// AddEdge adds a single directed edge from vertex u to v and updates the
+// strongly connected components. Returns true if the edge closed a cycle
+// between two or more components, merging them into one.
+func (s *IncrementalSCC) AddEdge(u, v int) bool {
+	s.g.AddEdge(u, v)
+	s.rev.AddEdge(v, u)
+
+	x, y := s.Component(u), s.Component(v)
+	lb, ub := s.ord[y], s.ord[x]
+
+	if x == y || lb > ub {
+		// The edge is internal or agrees with the current order
+		return false
+	}
+
+	// Discover the components reachable from y and the components that
+	// can reach x within the affected region. Any path from y to x only
+	// passes through components in this region.
+	var cycle bool
+	s.fwd, cycle = s.search(s.fwd[:0], s.g, y, x, lb, ub)
+	s.bwd, _ = s.search(s.bwd[:0], s.rev, x, y, lb, ub)
+
+	// Components visited by both searches lie on a cycle through the new
+	// edge, so merge them and remove them from fwd and bwd. Meanwhile,
+	// collect the topological indices of the affected region.
+	both := s.epoch + 1
+	merged := undefined
+	s.slots = s.slots[:0]
+
+	n := 0
+	for _, c := range s.fwd {
+		s.slots = append(s.slots, s.ord[c])
+
+		if s.mark[c] == s.epoch {
+			s.mark[c] = both
+			if merged == undefined {
+				merged = c
+			} else {
+				merged = s.union(merged, c)
+			}
+			continue
+		}
+
+		s.fwd[n] = c
+		n++
+	}
+	s.fwd = s.fwd[:n]
+
+	n = 0
+	for _, c := range s.bwd {
+		if s.mark[c] == both {
+			continue
+		}
+
+		s.slots = append(s.slots, s.ord[c])
+		s.bwd[n] = c
+		n++
+	}
+	s.bwd = s.bwd[:n]
+
+	impl.QuicksortIntSlice(s.slots)
+
+	// Reorder the affected region: components that reach x take the
+	// lowest indices, components reachable from y take the highest, and
+	// the merged component takes an index in between. Each group keeps its
+	// original relative order, so no component moves past a neighbor
+	// outside of the region.
+	s.sortByOrd(s.bwd)
+	s.sortByOrd(s.fwd)
+
+	for i, c := range s.bwd {
+		s.place(c, s.slots[i])
+	}
+
+	free := s.slots[len(s.bwd) : len(s.slots)-len(s.fwd)]
+	for _, o := range free {
+		s.at[o] = undefined
+	}
+	if merged != undefined {
+		s.place(merged, free[0])
+	}
+
+	for i, c := range s.fwd {
+		s.place(c, s.slots[len(s.slots)-len(s.fwd)+i])
+	}
+
+	return cycle
+}
+
+// search performs a DFS over the component graph of adj from representative
+// r, visiting only components whose topological index lies within [lb, ub].
+// Visited representatives are appended to dst, and found is true if target
+// was visited. All visited components are marked with a new search epoch.
+func (s *IncrementalSCC) search(dst []int, adj Graph, r, target, lb, ub int) (_ []int, found bool) {
+	s.epoch += 2 // Reserve odd epochs for AddEdge
+
+	dfs, done := &s.dfs, &s.done
+	dfs.pushOrPromote(r)
+
+	for dfs.len > 0 {
+		c := dfs.peek()
+
+		if s.mark[c] == s.epoch {
+			// Fully explored
+			done.push(dfs.pop())
+			continue
+		}
+
+		s.mark[c] = s.epoch
+		if c == target {
+			found = true
+		}
+
+		for m := c; ; {
+			for _, v := range adj[m] {
+				d := s.Component(v)
+				if s.mark[d] != s.epoch && lb <= s.ord[d] && s.ord[d] <= ub {
+					dfs.pushOrPromote(d)
+				}
+			}
+
+			if m = s.next[m]; m == c {
+				break
+			}
+		}
+	}
+
+	// Drain and reset the shared stack space
+	for done.len > 0 {
+		c := done.pop()
+		done.s[c] = listNode{prev: undefined, next: undefined}
+		dst = append(dst, c)
+	}
+
+	return dst, found
+}
+
+// union joins the components with representatives a and b and returns the
+// representative of the result.
+func (s *IncrementalSCC) union(a, b int) int {
+	if s.size[a] < s.size[b] {
+		a, b = b, a
+	}
+
+	s.parent[b] = a
+	s.size[a] += s.size[b]
+	s.next[a], s.next[b] = s.next[b], s.next[a] // Splice member lists
+	s.len--
+
+	return a
+}
+
+// sortByOrd sorts a slice of representatives by topological index.
+func (s *IncrementalSCC) sortByOrd(cs []int) {
+	for i := range cs {
+		cs[i] = s.ord[cs[i]]
+	}
+
+	impl.QuicksortIntSlice(cs)
+
+	for i := range cs {
+		cs[i] = s.at[cs[i]]
+	}
+}
+
+func (s *IncrementalSCC) place(c, o int) {
+	s.ord[c] = o
+	s.at[o] = c
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/generic/impl"
+)
+
+func TestIncrementalSCC(t *testing.T) {
+	data := []struct {
+		size   int
+		adj    map[int][]int
+		edges  [][2]int
+		merged []bool
+		scc    [][]int
+	}{
+		// Build a cycle one edge at a time
+		{
+			size:   4,
+			adj:    map[int][]int{},
+			edges:  [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {3, 1}},
+			merged: []bool{false, false, false, true, false},
+			scc:    [][]int{{0, 1, 2, 3}},
+		},
+		// Edges against the initial order
+		{
+			size:   4,
+			adj:    map[int][]int{},
+			edges:  [][2]int{{3, 2}, {2, 1}, {1, 0}, {0, 2}},
+			merged: []bool{false, false, false, true},
+			scc:    [][]int{{0, 1, 2}, {3}},
+		},
+		// Seeded components
+		{
+			size: 8,
+			adj: map[int][]int{
+				0: {4},
+				1: {0},
+				2: {1, 3},
+				3: {2},
+				4: {1},
+				5: {1, 4, 6},
+				6: {2, 5},
+				7: {3, 6, 7},
+			},
+			edges:  [][2]int{{7, 7}, {0, 2}, {1, 7}},
+			merged: []bool{false, true, true},
+			scc:    [][]int{{0, 1, 2, 3, 4, 5, 6, 7}},
+		},
+		// Self-loops do not merge components
+		{
+			size:   2,
+			adj:    map[int][]int{},
+			edges:  [][2]int{{0, 0}, {1, 1}},
+			merged: []bool{false, false},
+			scc:    [][]int{{0}, {1}},
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+
+		for u, edges := range row.adj {
+			for _, v := range edges {
+				g.AddEdge(u, v)
+			}
+		}
+
+		want := g.StronglyConnectedComponents(nil, w)
+		s, initial := NewIncrementalSCC(g, nil, w)
+
+		if !reflect.DeepEqual(initial, want) {
+			t.Errorf("%v != %v", initial, want)
+		}
+
+		for i, e := range row.edges {
+			if merged := s.AddEdge(e[0], e[1]); merged != row.merged[i] {
+				t.Errorf("AddEdge(%v, %v): %v != %v", e[0], e[1], merged, row.merged[i])
+			}
+		}
+
+		scc := incrementalSCCComponents(s)
+
+		if !reflect.DeepEqual(scc, row.scc) {
+			t.Errorf("%v != %v", scc, row.scc)
+		}
+		if s.Len() != len(row.scc) {
+			t.Errorf("%v != %v", s.Len(), len(row.scc))
+		}
+	}
+}
+
+func TestIncrementalSCCRandom(t *testing.T) {
+	const size = 50
+
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+	var initial [][]int
+
+	for trial := 0; trial < 20; trial++ {
+		var s *IncrementalSCC
+		s, initial = NewIncrementalSCC(make(Graph, size), initial, w)
+		h := make(Graph, size)
+		var scc [][]int
+
+		for i := 0; i < size*2; i++ {
+			u, v := r.Intn(size), r.Intn(size)
+			before := s.Len()
+			merged := s.AddEdge(u, v)
+			h.AddEdge(u, v)
+
+			if merged != (s.Len() < before) {
+				t.Errorf("merged: %v, but Len() %v -> %v", merged, before, s.Len())
+			}
+
+			// Compare components against StronglyConnectedComponents
+			scc = h.StronglyConnectedComponents(scc, w)
+			if len(scc) != s.Len() {
+				t.Fatalf("%v != %v", len(scc), s.Len())
+			}
+			for _, c := range scc {
+				for _, x := range c[1:] {
+					if s.Component(x) != s.Component(c[0]) {
+						t.Fatalf("%v and %v are not in the same component", x, c[0])
+					}
+				}
+			}
+
+			// Check topological order of components
+			for x := range h {
+				for _, y := range h[x] {
+					if s.Order(x) > s.Order(y) {
+						t.Fatalf("edge (%v,%v) out of order: %v > %v", x, y, s.Order(x), s.Order(y))
+					}
+				}
+			}
+		}
+	}
+}
+
+// incrementalSCCComponents returns the sorted components of s.
+func incrementalSCCComponents(s *IncrementalSCC) [][]int {
+	var scc [][]int
+	seen := make(map[int]bool)
+
+	for v := range s.Graph() {
+		r := s.Component(v)
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+
+		c := s.Members(nil, v)
+		impl.QuicksortIntSlice(c)
+		scc = append(scc, c)
+	}
+
+	return scc
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"sort"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+// WLHash returns a Weisfeiler-Lehman hash of the graph, which is invariant
+// under vertex relabeling: isomorphic graphs always have the same hash.
+//
+// Every vertex starts with the same color, and in each of rounds refinement
+// steps, the color of each vertex is replaced by a hash of its color and the
+// multisets of the colors of its successors and predecessors. The result is
+// a hash of the number of vertices and the multiset of final colors.
+//
+// Graphs with different hashes are never isomorphic, but graphs with equal
+// hashes may not be: Weisfeiler-Lehman refinement cannot distinguish some
+// graphs, such as regular graphs of the same size and degree, and hashes may
+// collide. Use Isomorphism to check equality exactly. A few rounds are
+// typically sufficient to distinguish most graphs; more than |V| rounds are
+// never useful.
+func (g Graph) WLHash(rounds int, w *Workspace) uint64 {
+	return WLHash(g, rounds, w)
+}
+
+// Isomorphism searches for an isomorphism from the graph to h: a one-to-one
+// mapping of the vertices of g to the vertices of h such that the number of
+// edges from u to v in g is equal to the number of edges from mapping[u] to
+// mapping[v] in h, for all vertices u and v.
+//
+// The mapping is written to mapping, which is grown if necessary. If the
+// graphs are not isomorphic, an empty mapping and false are returned.
+//
+// This is a VF2-style backtracking search [1]: vertices of g are matched in
+// breadth-first order, ignoring edge direction, so each vertex is matched
+// against the neighbors of the image of an already matched vertex, and every
+// candidate pair is checked against all edges to previously matched vertices.
+// Candidates are pruned by their Weisfeiler-Lehman colors (see WLHash). The
+// worst case is exponential, so this is intended for modest graph sizes.
+//
+// Note that the predecessors of every vertex are found with Transpose, which
+// allocates a Graph for each of g and h, and that O(|V|) additional memory is
+// allocated for the search state.
+//
+// [1]: https://doi.org/10.1109/TPAMI.2004.75
+func (g Graph) Isomorphism(h Graph, mapping []int, w *Workspace) ([]int, bool) {
+	return Isomorphism(g, h, mapping, w)
+}
+
+// WLHash is like (Graph).WLHash, but accepts any Adjacency.
+func WLHash[A Adjacency](g A, rounds int, w *Workspace) uint64 {
+	n := g.Len()
+	w.prepare(n, 0)
+
+	colors := wlColors(g, rounds, w)
+
+	var sum uint64
+	for _, c := range colors {
+		sum += mix64(uint64(c))
+	}
+
+	return mix64(sum ^ mix64(uint64(n)))
+}
+
+// Isomorphism is like (Graph).Isomorphism, but accepts any Adjacency.
+func Isomorphism[A, B Adjacency](g A, h B, mapping []int, w *Workspace) ([]int, bool) {
+	n := g.Len()
+	mapping = resizeIntSlice(mapping, n)
+
+	if h.Len() != n || countEdges(g) != countEdges(h) {
+		return mapping[:0], false
+	}
+
+	w.prepare(n, 0)
+
+	// Search state; the parent of each vertex in the matching order is
+	// stored as 2p for an edge from p, or 2p+1 for an edge to p.
+	state := make([]int, 4*n)
+	colorG, colorH, parent, count := state[:n], state[n:2*n], state[2*n:3*n], state[3*n:]
+
+	copy(colorG, wlColors(g, isomorphismRounds, w))
+	copy(colorH, wlColors(h, isomorphismRounds, w))
+
+	// Compare the multisets of colors
+	sortedG, sortedH := w.a[:n], w.b[:n]
+	copy(sortedG, colorG)
+	copy(sortedH, colorH)
+	sort.Ints(sortedG)
+	sort.Ints(sortedH)
+
+	for i := range sortedG {
+		if sortedG[i] != sortedH[i] {
+			return mapping[:0], false
+		}
+	}
+
+	gt, ht := Transpose(g, nil), Transpose(h, nil)
+
+	w.reset(wCNeg)
+
+	order := w.a[:0] // |V|w · Vertices of g in matching order
+	cursor := w.b    // |V|w · Slice of depth -> index of next candidate
+	inverse := w.c   // |V|w · Slice of vertex of h -> vertex of g, or undefined
+	seen := bitslice.Make(n)
+
+	for s := 0; s < n; s++ {
+		if seen.Get(s) {
+			continue
+		}
+
+		seen.Set(s)
+		parent[s] = undefined
+		order = append(order, s)
+
+		for i := len(order) - 1; i < len(order); i++ {
+			u := order[i]
+
+			for _, v := range g.Neighbors(u) {
+				if !seen.Get(v) {
+					seen.Set(v)
+					parent[v] = 2 * u
+					order = append(order, v)
+				}
+			}
+
+			for _, v := range gt.Neighbors(u) {
+				if !seen.Get(v) {
+					seen.Set(v)
+					parent[v] = 2*u + 1
+					order = append(order, v)
+				}
+			}
+		}
+	}
+
+	for i := range mapping {
+		mapping[i] = undefined
+	}
+
+	// feasible returns true if x can be matched with y; i.e. they have the
+	// same color, y is unmatched, and all edges between x and matched
+	// vertices of g correspond to the edges between y and matched vertices
+	// of h.
+	feasible := func(x, y int) bool {
+		if colorG[x] != colorH[y] || inverse[y] != undefined {
+			return false
+		}
+
+		return matchingEdges(g, h, mapping, inverse, count, x, y) &&
+			matchingEdges(gt, ht, mapping, inverse, count, x, y)
+	}
+
+	i := 0
+	if n > 0 {
+		cursor[0] = 0
+	}
+
+	for i >= 0 && i < n {
+		x := order[i]
+
+		// Candidates are all vertices of h, or the neighbors of the
+		// image of the parent of x in h (or ht if x is a predecessor of
+		// its parent). The neighbors are fetched again for every
+		// candidate, since feasible also calls h.Neighbors.
+		image, out := undefined, false
+
+		if p := parent[x]; p != undefined {
+			image, out = mapping[p>>1], p&1 == 0
+		}
+
+		y := undefined
+
+		for y == undefined {
+			c := cursor[i]
+
+			if image == undefined {
+				if c == n {
+					break
+				}
+			} else {
+				var edges []int
+				if out {
+					edges = h.Neighbors(image)
+				} else {
+					edges = ht.Neighbors(image)
+				}
+				if c == len(edges) {
+					break
+				}
+				c = edges[c]
+			}
+
+			cursor[i]++
+
+			if feasible(x, c) {
+				y = c
+			}
+		}
+
+		if y == undefined {
+			// Backtrack
+			i--
+			if i >= 0 {
+				x := order[i]
+				inverse[mapping[x]] = undefined
+				mapping[x] = undefined
+			}
+			continue
+		}
+
+		mapping[x], inverse[y] = y, x
+		i++
+
+		if i < n {
+			cursor[i] = 0
+		}
+	}
+
+	if i < 0 {
+		return mapping[:0], false
+	}
+
+	return mapping, true
+}
+
+// isomorphismRounds is the number of Weisfeiler-Lehman refinement steps used
+// to prune candidates in Isomorphism.
+const isomorphismRounds = 3
+
+// matchingEdges returns true if the edges from x to itself and matched
+// vertices of g correspond to the edges from y to itself and matched vertices
+// of h, with multiplicity, assuming that x is matched with y. The count slice
+// is indexed by vertices of h, and must be zero-filled. It is zero-filled on
+// return.
+//
+// The edges of x and y are fetched again for each pass, so that no slice
+// returned by Neighbors is retained across another call, even if g and h are
+// the same Adjacency.
+func matchingEdges[A, B Adjacency](g A, h B, mapping, inverse, count []int, x, y int) bool {
+	for _, u := range g.Neighbors(x) {
+		if u == x {
+			count[y]++
+		} else if v := mapping[u]; v != undefined {
+			count[v]++
+		}
+	}
+
+	for _, v := range h.Neighbors(y) {
+		if v == y || inverse[v] != undefined {
+			count[v]--
+		}
+	}
+
+	ok := true
+
+	for _, u := range g.Neighbors(x) {
+		v := mapping[u]
+		if u == x {
+			v = y
+		}
+		if v != undefined {
+			if count[v] != 0 {
+				ok = false
+			}
+			count[v] = 0
+		}
+	}
+
+	for _, v := range h.Neighbors(y) {
+		if count[v] != 0 {
+			ok = false
+		}
+		count[v] = 0
+	}
+
+	return ok
+}
+
+// wlColors computes the Weisfeiler-Lehman colors of the vertices of g after
+// the given number of refinement steps, using the first three fields of w,
+// and returns a slice of vertex -> color that is backed by w.
+func wlColors[A Adjacency](g A, rounds int, w *Workspace) []int {
+	color := w.a // |V|w · Slice of vertex -> color
+	out := w.b   // |V|w · Slice of vertex -> hash of multiset of successor colors
+	in := w.c    // |V|w · Slice of vertex -> hash of multiset of predecessor colors
+
+	for i := range color {
+		color[i] = 0
+	}
+
+	for r := 0; r < rounds; r++ {
+		for v := range color {
+			out[v], in[v] = 0, 0
+		}
+
+		// Sums of hashes are hashes of multisets, and are independent of
+		// the order of edges.
+		for u := range color {
+			cu := mix64(uint64(color[u]) ^ wlInSalt)
+
+			for _, v := range g.Neighbors(u) {
+				out[u] += int(mix64(uint64(color[v]) ^ wlOutSalt))
+				in[v] += int(cu)
+			}
+		}
+
+		for v := range color {
+			color[v] = int(mix64(uint64(color[v]) + mix64(uint64(out[v])+wlOutSalt) + mix64(uint64(in[v])+wlInSalt)))
+		}
+	}
+
+	return color
+}
+
+// Arbitrary constants that distinguish successors from predecessors
+const (
+	wlOutSalt = 0x9e3779b97f4a7c15
+	wlInSalt  = 0xc2b2ae3d27d4eb4f
+)
+
+// mix64 is the finalizer of the SplitMix64 generator, which is a bijective
+// mixing function.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// countEdges returns the total number of edges in g.
+func countEdges[A Adjacency](g A) int {
+	m := 0
+	for u := 0; u < g.Len(); u++ {
+		m += len(g.Neighbors(u))
+	}
+
+	return m
+}
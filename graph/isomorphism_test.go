@@ -0,0 +1,278 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// relabel returns a copy of g with every vertex v renamed to perm[v], and
+// with the edges of each vertex shuffled.
+func relabel(r *rand.Rand, g Graph, perm []int) Graph {
+	h := make(Graph, len(g))
+
+	for u := range g {
+		for _, v := range g[u] {
+			h.AddEdge(perm[u], perm[v])
+		}
+	}
+
+	for u := range h {
+		r.Shuffle(len(h[u]), func(i, j int) { h[u][i], h[u][j] = h[u][j], h[u][i] })
+	}
+
+	return h
+}
+
+// isIsomorphism returns true if mapping is an isomorphism from g to h.
+func isIsomorphism(g, h Graph, mapping []int) bool {
+	if len(mapping) != len(g) || len(g) != len(h) {
+		return false
+	}
+
+	inverse := make([]bool, len(h))
+	for _, v := range mapping {
+		if v < 0 || v >= len(h) || inverse[v] {
+			return false
+		}
+		inverse[v] = true
+	}
+
+	count := make(map[[2]int]int)
+	for u := range g {
+		for _, v := range g[u] {
+			count[[2]int{mapping[u], mapping[v]}]++
+		}
+	}
+	for u := range h {
+		for _, v := range h[u] {
+			count[[2]int{u, v}]--
+		}
+	}
+
+	for _, c := range count {
+		if c != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestGraphIsomorphism(t *testing.T) {
+	data := []struct {
+		g, h       Graph
+		isomorphic bool
+		sameHash   bool
+	}{
+		{g: Graph{}, h: Graph{}, isomorphic: true, sameHash: true},
+		{g: Graph{{}}, h: Graph{}, isomorphic: false, sameHash: false},
+		{g: Graph{{1}, {}}, h: Graph{{}, {0}}, isomorphic: true, sameHash: true},
+		{g: Graph{{1}, {2}, {}}, h: Graph{{1}, {}, {1}}, isomorphic: false, sameHash: false},
+		{g: Graph{{1}, {2}, {}}, h: Graph{{}, {0, 2}, {}}, isomorphic: false, sameHash: false},
+		{g: Graph{{0, 1}, {}}, h: Graph{{}, {0, 1}}, isomorphic: true, sameHash: true},
+		{g: Graph{{1, 1}, {}}, h: Graph{{1}, {0}}, isomorphic: false, sameHash: false},
+		{g: Graph{{1, 1}, {}}, h: Graph{{}, {0, 0}}, isomorphic: true, sameHash: true},
+		{
+			// Two directed 3-cycles, relabeled
+			g:          Graph{{1}, {2}, {0}, {4}, {5}, {3}},
+			h:          Graph{{3}, {4}, {1}, {5}, {2}, {0}},
+			isomorphic: true,
+			sameHash:   true,
+		},
+		{
+			// Two 3-cycles and a 6-cycle cannot be distinguished by
+			// Weisfeiler-Lehman refinement
+			g:          Graph{{1}, {2}, {0}, {4}, {5}, {3}},
+			h:          Graph{{1}, {2}, {3}, {4}, {5}, {0}},
+			isomorphic: false,
+			sameHash:   true,
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		mapping, ok := row.g.Isomorphism(row.h, nil, w)
+
+		if ok != row.isomorphic {
+			t.Errorf("%v, %v: %v != %v", row.g, row.h, ok, row.isomorphic)
+		}
+		if ok && !isIsomorphism(row.g, row.h, mapping) {
+			t.Errorf("%v is not an isomorphism of %v and %v", mapping, row.g, row.h)
+		}
+		if !ok && len(mapping) != 0 {
+			t.Errorf("%v != %v", len(mapping), 0)
+		}
+
+		sameHash := row.g.WLHash(3, w) == row.h.WLHash(3, w)
+		if sameHash != row.sameHash {
+			t.Errorf("%v != %v", sameHash, row.sameHash)
+		}
+	}
+}
+
+func TestRandomGraphIsomorphism(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+	var mapping []int
+
+	for i := 0; i < 200; i++ {
+		size := r.Intn(30)
+		g := make(Graph, size)
+		p := r.Float64() * 0.2
+
+		for u := range g {
+			for v := range g {
+				if r.Float64() < p {
+					g.AddEdge(u, v)
+				}
+			}
+		}
+
+		h := relabel(r, g, r.Perm(size))
+
+		if g.WLHash(4, w) != h.WLHash(4, w) {
+			t.Errorf("WLHash(%v) != WLHash(%v)", g, h)
+		}
+
+		var ok bool
+		mapping, ok = Isomorphism(NewCSR(g), h, mapping, w)
+		if !ok || !isIsomorphism(g, h, mapping) {
+			t.Errorf("%v is not an isomorphism of %v and %v", mapping, g, h)
+		}
+
+	}
+}
+
+// permutations calls f with every permutation of [0, n).
+func permutations(n int, f func(perm []int)) {
+	perm := make([]int, n)
+	used := make([]bool, n)
+
+	var rec func(i int)
+	rec = func(i int) {
+		if i == n {
+			f(perm)
+			return
+		}
+		for v := 0; v < n; v++ {
+			if !used[v] {
+				used[v] = true
+				perm[i] = v
+				rec(i + 1)
+				used[v] = false
+			}
+		}
+	}
+
+	rec(0)
+}
+
+func TestSmallGraphIsomorphism(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	randomGraph := func(size, edges int) Graph {
+		g := make(Graph, size)
+		for i := 0; i < edges; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+		return g
+	}
+
+	for i := 0; i < 300; i++ {
+		size := 1 + r.Intn(6)
+		edges := r.Intn(2 * size)
+		g, h := randomGraph(size, edges), randomGraph(size, edges)
+
+		expected := false
+		permutations(size, func(perm []int) {
+			expected = expected || isIsomorphism(g, h, perm)
+		})
+
+		mapping, ok := g.Isomorphism(h, nil, w)
+		if ok != expected {
+			t.Errorf("%v, %v: %v != %v", g, h, ok, expected)
+		}
+		if ok && !isIsomorphism(g, h, mapping) {
+			t.Errorf("%v is not an isomorphism of %v and %v", mapping, g, h)
+		}
+		if ok && g.WLHash(3, w) != h.WLHash(3, w) {
+			t.Errorf("WLHash(%v) != WLHash(%v)", g, h)
+		}
+	}
+}
+
+// poisonedAdjacency wraps a Graph, but returns every row in alternating
+// halves of a single reused buffer, and overwrites the previous row with an
+// invalid vertex, so that retaining a slice across calls to Neighbors
+// produces garbage.
+type poisonedAdjacency struct {
+	g    Graph
+	buf  []int
+	prev []int
+}
+
+func (a *poisonedAdjacency) Len() int {
+	return len(a.g)
+}
+
+func (a *poisonedAdjacency) Neighbors(u int) []int {
+	if a.buf == nil {
+		max := 0
+		for _, edges := range a.g {
+			if len(edges) > max {
+				max = len(edges)
+			}
+		}
+		a.buf = make([]int, 2*max)
+	}
+
+	for i := range a.prev {
+		a.prev[i] = 1 << 40
+	}
+
+	half := len(a.buf) / 2
+	offset := 0
+	if len(a.prev) > 0 && &a.prev[:1][0] == &a.buf[0] {
+		offset = half
+	}
+
+	a.prev = a.buf[offset : offset+copy(a.buf[offset:offset+half], a.g[u])]
+	return a.prev
+}
+
+func TestIsomorphismReusedBuffer(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for i := 0; i < 100; i++ {
+		size := 1 + r.Intn(20)
+		g := make(Graph, size)
+		for j := 0; j < 2*size; j++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+		h := relabel(r, g, r.Perm(size))
+
+		a, b := &poisonedAdjacency{g: g}, &poisonedAdjacency{g: h}
+
+		mapping, ok := Isomorphism(a, b, nil, w)
+		if !ok || !isIsomorphism(g, h, mapping) {
+			t.Errorf("%v is not an isomorphism of %v and %v", mapping, g, h)
+		}
+
+		// The same Adjacency on both sides
+		mapping, ok = Isomorphism(a, a, nil, w)
+		if !ok || !isIsomorphism(g, g, mapping) {
+			t.Errorf("%v is not an automorphism of %v", mapping, g)
+		}
+
+		if WLHash(a, 3, w) != g.WLHash(3, w) {
+			t.Errorf("%v != %v", WLHash(a, 3, w), g.WLHash(3, w))
+		}
+	}
+}
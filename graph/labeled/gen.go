@@ -0,0 +1,6 @@
+// Package labeled provides graphs whose vertices are identified by arbitrary
+// keys instead of dense integer indices. The implementations are generated
+// from the templates in package labeled/template.
+package labeled
+
+//go:generate genny -pkg=labeled -in=template/labeledgraph.go -out=stringgraph.go gen GenericType=string
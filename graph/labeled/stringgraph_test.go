@@ -0,0 +1,114 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package labeled
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/graph"
+)
+
+func TestStringGraph(t *testing.T) {
+	// Build dependency graph: an edge from u to v means u must precede v
+	g := NewStringGraph(0)
+	w := graph.NewWorkspace(0)
+
+	for _, e := range [][2]string{
+		{"fetch", "build"}, {"configure", "build"}, {"build", "test"},
+		{"build", "install"}, {"test", "install"}, {"", "fetch"},
+	} {
+		edge := g.AddEdge(e[0], e[1])
+		if g.Key(edge.U) != e[0] || g.Key(edge.V) != e[1] || g.Neighbors(edge.U)[edge.I] != edge.V {
+			t.Errorf("%v != %v", edge, e)
+		}
+	}
+
+	if g.Len() != 6 || len(g.Graph()) != 6 {
+		t.Errorf("%v != %v", g.Len(), 6)
+	}
+
+	// The empty string is an ordinary key
+	if v, ok := g.Index(""); !ok || g.Key(v) != "" {
+		t.Errorf("%v != %v", ok, true)
+	}
+	if _, ok := g.Index("deploy"); ok {
+		t.Errorf("unexpected key: %v", "deploy")
+	}
+
+	// Vertex interns new keys without edges
+	n := g.Len()
+	if v := g.Vertex("deploy"); v != n || g.Len() != n+1 || len(g.Neighbors(v)) != 0 {
+		t.Errorf("%v != %v", v, n)
+	}
+	if v := g.Vertex("deploy"); v != n || g.Len() != n+1 {
+		t.Errorf("%v != %v", v, n)
+	}
+
+	tsort := g.TopologicalSort(nil, w)
+	if len(tsort) != g.Len() {
+		t.Errorf("%v != %v", len(tsort), g.Len())
+	}
+
+	position := make(map[string]int, len(tsort))
+	for i, k := range tsort {
+		position[k] = i
+	}
+	for u := 0; u < g.Len(); u++ {
+		for _, v := range g.Neighbors(u) {
+			if position[g.Key(u)] >= position[g.Key(v)] {
+				t.Errorf("%v does not precede %v in %v", g.Key(u), g.Key(v), tsort)
+			}
+		}
+	}
+
+	if tsort, cycle := g.TopologicalSortOrCycle(nil, nil, w); len(tsort) != g.Len() || len(cycle) != 0 {
+		t.Errorf("%v != %v", cycle, []string{})
+	}
+
+	// Close a cycle
+	g.AddEdge("install", "fetch")
+
+	if tsort, cycle := g.TopologicalSortOrCycle(tsort, nil, w); len(tsort) != 0 || len(cycle) == 0 || cycle[0] != cycle[len(cycle)-1] {
+		t.Errorf("%v, %v", tsort, cycle)
+	}
+
+	scc := g.StronglyConnectedComponents(nil, w)
+	if len(scc) != 4 {
+		t.Errorf("%v != %v", len(scc), 4)
+	}
+	for _, c := range scc {
+		if len(c) > 1 && len(c) != 4 {
+			t.Errorf("%v != %v", len(c), 4)
+		}
+	}
+
+	// Components are numbered in topological order
+	h, comp := g.Condensation(nil, nil, w)
+	if len(h) != 4 || len(comp) != g.Len() {
+		t.Errorf("%v != %v", len(comp), g.Len())
+	}
+	if comp["fetch"] != comp["install"] || comp[""] >= comp["fetch"] || comp["configure"] >= comp["build"] {
+		t.Errorf("invalid components: %v", comp)
+	}
+
+	// Keys reuses dst
+	dst := make([]string, 0, 8)
+	if keys := g.Keys(dst, []int{0, 1}); &keys[0] != &dst[:1][0] || !reflect.DeepEqual(keys, []string{g.Key(0), g.Key(1)}) {
+		t.Errorf("%v != %v", keys, []string{g.Key(0), g.Key(1)})
+	}
+
+	// Transpose preserves keys and reverses edges
+	tr := g.Transpose()
+	if tr.Len() != g.Len() {
+		t.Errorf("%v != %v", tr.Len(), g.Len())
+	}
+	if path := tr.LeastEdgesPath(nil, "test", "fetch", w); !reflect.DeepEqual(path, []string{"test", "build", "fetch"}) {
+		t.Errorf("%v != %v", path, []string{"test", "build", "fetch"})
+	}
+	if path := g.LeastEdgesPath(nil, "test", "fetch", w); !reflect.DeepEqual(path, []string{"test", "install", "fetch"}) {
+		t.Errorf("%v != %v", path, []string{"test", "install", "fetch"})
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package template
+
+import (
+	"math"
+
+	"github.com/guns/golibs/graph"
+)
+
+// GenericTypeGraph is a graph.Graph whose vertices are identified by keys of
+// type GenericType. Keys are interned on first use and mapped to dense vertex
+// indices, so the underlying graph.Graph can be used directly with any
+// algorithm of package graph, and results can be translated back to keys with
+// Key and Keys.
+//
+// Methods that accept keys treat an unknown key as a vertex without edges.
+//
+// Algorithms whose results are matrices indexed by vertex, such as
+// AllPairsShortestPaths and TransitiveClosure, are not wrapped. Call them on
+// Graph() and translate vertices with Key.
+type GenericTypeGraph struct {
+	g     graph.Graph
+	keys  []GenericType       // Vertex -> key
+	index map[GenericType]int // Key -> vertex
+	buf   []int               // Scratch space for vertex results
+	cycle []int               // Scratch space for cycle results
+	rows  [][]int             // Scratch space for vertex groups
+	edges []graph.Edge        // Scratch space for edge results
+}
+
+// NewGenericTypeGraph returns a new empty GenericTypeGraph with room for
+// size vertices.
+func NewGenericTypeGraph(size int) *GenericTypeGraph {
+	return &GenericTypeGraph{
+		g:     make(graph.Graph, 0, size),
+		keys:  make([]GenericType, 0, size),
+		index: make(map[GenericType]int, size),
+	}
+}
+
+// Graph returns the underlying graph.Graph. Vertex v of the returned graph
+// is identified by Key(v). The returned graph must not be modified, except
+// through the methods of g.
+func (g *GenericTypeGraph) Graph() graph.Graph {
+	return g.g
+}
+
+// Len returns the number of vertices in the graph.
+func (g *GenericTypeGraph) Len() int {
+	return len(g.g)
+}
+
+// Neighbors returns the heads of all edges from vertex u. With Len, this
+// implements graph.Adjacency.
+func (g *GenericTypeGraph) Neighbors(u int) []int {
+	return g.g[u]
+}
+
+// Vertex returns the vertex index of key, adding a new vertex if key is not
+// yet in the graph.
+func (g *GenericTypeGraph) Vertex(key GenericType) int {
+	if v, ok := g.index[key]; ok {
+		return v
+	}
+
+	v := len(g.g)
+	g.g = append(g.g, nil)
+	g.keys = append(g.keys, key)
+	g.index[key] = v
+
+	return v
+}
+
+// Index returns the vertex index of key, and false if key is not in the
+// graph.
+func (g *GenericTypeGraph) Index(key GenericType) (int, bool) {
+	v, ok := g.index[key]
+	return v, ok
+}
+
+// Key returns the key of vertex v.
+func (g *GenericTypeGraph) Key(v int) GenericType {
+	return g.keys[v]
+}
+
+// Keys writes the keys of the vertices vs to dst, which is grown if
+// necessary, and returns the result.
+func (g *GenericTypeGraph) Keys(dst []GenericType, vs []int) []GenericType {
+	dst = dst[:0]
+
+	for _, v := range vs {
+		dst = append(dst, g.keys[v])
+	}
+
+	return dst
+}
+
+// AddEdge adds a single directed edge from key u to v, adding vertices as
+// necessary. The new edge is returned so that parallel data, such as edge
+// weights, can be updated.
+func (g *GenericTypeGraph) AddEdge(u, v GenericType) graph.Edge {
+	x, y := g.Vertex(u), g.Vertex(v)
+	g.g.AddEdge(x, y)
+
+	return graph.Edge{U: x, V: y, I: len(g.g[x]) - 1}
+}
+
+// LeastEdgesPath is like (graph.Graph).LeastEdgesPath.
+func (g *GenericTypeGraph) LeastEdgesPath(path []GenericType, u, v GenericType, w *graph.Workspace) []GenericType {
+	x, ok := g.index[u]
+	y, ok2 := g.index[v]
+	if !ok || !ok2 {
+		return path[:0]
+	}
+
+	g.buf = g.g.LeastEdgesPath(g.buf, x, y, w)
+
+	return g.Keys(path, g.buf)
+}
+
+// ShortestPath is like (graph.Graph).ShortestPath. The weights must be
+// parallel to Graph().
+func (g *GenericTypeGraph) ShortestPath(path []GenericType, u, v GenericType, weights [][]float64, w *graph.Workspace) ([]GenericType, float64) {
+	x, ok := g.index[u]
+	y, ok2 := g.index[v]
+	if !ok || !ok2 {
+		return path[:0], math.Inf(1)
+	}
+
+	var dist float64
+	g.buf, dist = g.g.ShortestPath(g.buf, x, y, weights, w)
+
+	return g.Keys(path, g.buf), dist
+}
+
+// BellmanFordPath is like (graph.Graph).BellmanFordPath. The weights must be
+// parallel to Graph(). If negCycle is true, p is a negative cycle as a closed
+// walk of keys.
+func (g *GenericTypeGraph) BellmanFordPath(path []GenericType, u, v GenericType, weights [][]float64, w *graph.Workspace) (p []GenericType, dist float64, negCycle bool) {
+	x, ok := g.index[u]
+	y, ok2 := g.index[v]
+	if !ok || !ok2 {
+		return path[:0], math.Inf(1), false
+	}
+
+	g.buf, dist, negCycle = g.g.BellmanFordPath(g.buf, x, y, weights, w)
+
+	return g.Keys(path, g.buf), dist, negCycle
+}
+
+// TopologicalSort is like (graph.Graph).TopologicalSort.
+func (g *GenericTypeGraph) TopologicalSort(tsort []GenericType, w *graph.Workspace) []GenericType {
+	g.buf = g.g.TopologicalSort(g.buf, w)
+	return g.Keys(tsort, g.buf)
+}
+
+// TopologicalSortOrCycle is like (graph.Graph).TopologicalSortOrCycle.
+func (g *GenericTypeGraph) TopologicalSortOrCycle(tsort, cycle []GenericType, w *graph.Workspace) ([]GenericType, []GenericType) {
+	g.buf, g.cycle = g.g.TopologicalSortOrCycle(g.buf, g.cycle, w)
+	return g.Keys(tsort, g.buf), g.Keys(cycle, g.cycle)
+}
+
+// StronglyConnectedComponents is like
+// (graph.Graph).StronglyConnectedComponents.
+func (g *GenericTypeGraph) StronglyConnectedComponents(scc [][]GenericType, w *graph.Workspace) [][]GenericType {
+	g.rows = g.g.StronglyConnectedComponents(g.rows, w)
+	return g.keyRows(scc, g.rows)
+}
+
+// WeaklyConnectedComponents is like
+// (graph.Graph).WeaklyConnectedComponents.
+func (g *GenericTypeGraph) WeaklyConnectedComponents(wcc [][]GenericType, w *graph.Workspace) [][]GenericType {
+	g.rows = g.g.WeaklyConnectedComponents(g.rows, w)
+	return g.keyRows(wcc, g.rows)
+}
+
+// ArticulationPoints is like (graph.Graph).ArticulationPoints.
+func (g *GenericTypeGraph) ArticulationPoints(points []GenericType, w *graph.Workspace) []GenericType {
+	g.buf = g.g.ArticulationPoints(g.buf, w)
+	return g.Keys(points, g.buf)
+}
+
+// Bridges is like (graph.Graph).Bridges, but returns the keys of the
+// endpoints of each bridge.
+func (g *GenericTypeGraph) Bridges(bridges [][2]GenericType, w *graph.Workspace) [][2]GenericType {
+	g.edges = g.g.Bridges(g.edges, w)
+	return g.keyEdges(bridges, g.edges)
+}
+
+// Condensation is like (graph.Graph).Condensation, but writes the component
+// index of every key to comp, which is allocated if nil. Vertex a of the
+// returned condensation h is the component a.
+func (g *GenericTypeGraph) Condensation(h graph.Graph, comp map[GenericType]int, w *graph.Workspace) (graph.Graph, map[GenericType]int) {
+	h, g.buf, g.rows = g.g.Condensation(h, g.buf, g.rows, w)
+
+	if comp == nil {
+		comp = make(map[GenericType]int, len(g.keys))
+	}
+	for k := range comp {
+		delete(comp, k)
+	}
+	for v, c := range g.buf {
+		comp[g.keys[v]] = c
+	}
+
+	return h, comp
+}
+
+// KruskalForest is like (graph.Graph).KruskalForest, but returns the keys of
+// the endpoints of each edge of the forest. The weights must be parallel to
+// Graph().
+func (g *GenericTypeGraph) KruskalForest(forest [][2]GenericType, weights [][]float64, w *graph.Workspace) ([][2]GenericType, float64) {
+	var total float64
+	g.edges, total = g.g.KruskalForest(g.edges, weights, w)
+	return g.keyEdges(forest, g.edges), total
+}
+
+// PrimForest is like (graph.Graph).PrimForest, but returns the keys of the
+// endpoints of each edge of the forest. The weights must be parallel to
+// Graph().
+func (g *GenericTypeGraph) PrimForest(forest [][2]GenericType, weights [][]float64, w *graph.Workspace) ([][2]GenericType, float64) {
+	var total float64
+	g.edges, total = g.g.PrimForest(g.edges, weights, w)
+	return g.keyEdges(forest, g.edges), total
+}
+
+// Transpose returns a copy of g with all edges reversed and the same vertex
+// indices.
+func (g *GenericTypeGraph) Transpose() *GenericTypeGraph {
+	t := &GenericTypeGraph{
+		g:     g.g.Transpose(nil),
+		keys:  append([]GenericType(nil), g.keys...),
+		index: make(map[GenericType]int, len(g.keys)),
+	}
+
+	for k, v := range g.index {
+		t.index[k] = v
+	}
+
+	return t
+}
+
+// keyEdges writes the keys of the endpoints of edges to dst, which is grown
+// if necessary, and returns the result.
+func (g *GenericTypeGraph) keyEdges(dst [][2]GenericType, edges []graph.Edge) [][2]GenericType {
+	dst = dst[:0]
+
+	for _, e := range edges {
+		dst = append(dst, [2]GenericType{g.keys[e.U], g.keys[e.V]})
+	}
+
+	return dst
+}
+
+// keyRows translates groups of vertices to groups of keys. The result is
+// backed by a single slice (dst[0][:cap(dst[0])]), which is reused if
+// possible.
+func (g *GenericTypeGraph) keyRows(dst [][]GenericType, rows [][]int) [][]GenericType {
+	size := 0
+	for i := range rows {
+		size += len(rows[i])
+	}
+
+	var buf []GenericType
+
+	if cap(dst) > 0 {
+		buf = dst[:1][0]
+		buf = buf[:cap(buf)]
+	}
+
+	if len(buf) < size {
+		buf = make([]GenericType, size)
+	}
+
+	dst = dst[:0]
+	offset := 0
+
+	for _, row := range rows {
+		keys := buf[offset : offset : offset+len(row)]
+		dst = append(dst, g.Keys(keys, row))
+		offset += len(row)
+	}
+
+	return dst
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package template
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/graph"
+)
+
+func TestLabeledGraph(t *testing.T) {
+	type T = GenericType
+
+	g := NewGenericTypeGraph(0)
+	w := graph.NewWorkspace(0)
+
+	weights := [][]float64{}
+	for _, e := range []struct {
+		u, v   T
+		weight float64
+	}{
+		{"a", "b", 1}, {"b", "c", 1}, {"c", "a", 1}, {"c", "d", 5}, {"a", "d", 10},
+	} {
+		edge := g.AddEdge(e.u, e.v)
+		for len(weights) <= edge.U {
+			weights = append(weights, nil)
+		}
+		weights[edge.U] = append(weights[edge.U], e.weight)
+	}
+	for len(weights) < g.Len() {
+		weights = append(weights, nil)
+	}
+
+	if g.Len() != 4 {
+		t.Errorf("%v != %v", g.Len(), 4)
+	}
+	if v, ok := g.Index("c"); !ok || v != 2 || g.Key(v) != "c" {
+		t.Errorf("%v != %v", v, 2)
+	}
+	if _, ok := g.Index("e"); ok {
+		t.Errorf("unexpected key: %v", "e")
+	}
+	if v := g.Vertex("b"); v != 1 {
+		t.Errorf("%v != %v", v, 1)
+	}
+
+	if path := g.LeastEdgesPath(nil, "a", "d", w); !reflect.DeepEqual(path, []T{"a", "d"}) {
+		t.Errorf("%v != %v", path, []T{"a", "d"})
+	}
+	if path := g.LeastEdgesPath(nil, "a", "e", w); len(path) != 0 {
+		t.Errorf("%v != %v", path, []T{})
+	}
+
+	path, dist := g.ShortestPath(nil, "a", "d", weights, w)
+	if !reflect.DeepEqual(path, []T{"a", "b", "c", "d"}) || dist != 7 {
+		t.Errorf("%v, %v != %v, %v", path, dist, []T{"a", "b", "c", "d"}, 7)
+	}
+	if _, dist := g.ShortestPath(nil, "e", "a", weights, w); !math.IsInf(dist, 1) {
+		t.Errorf("%v != %v", dist, math.Inf(1))
+	}
+
+	path, dist, negCycle := g.BellmanFordPath(nil, "a", "d", weights, w)
+	if !reflect.DeepEqual(path, []T{"a", "b", "c", "d"}) || dist != 7 || negCycle {
+		t.Errorf("%v, %v, %v != %v, %v, %v", path, dist, negCycle, []T{"a", "b", "c", "d"}, 7, false)
+	}
+	if _, dist, _ := g.BellmanFordPath(nil, "a", "e", weights, w); !math.IsInf(dist, 1) {
+		t.Errorf("%v != %v", dist, math.Inf(1))
+	}
+
+	// c -> a is the first edge of c
+	negWeights := [][]float64{weights[0], weights[1], {-5, 5}, nil}
+	path, dist, negCycle = g.BellmanFordPath(path, "a", "d", negWeights, w)
+	if !negCycle || dist != -3 || len(path) != 4 || path[0] != path[3] {
+		t.Errorf("%v, %v, %v", path, dist, negCycle)
+	}
+
+	h, comp := g.Condensation(nil, nil, w)
+	if !reflect.DeepEqual(h, graph.Graph{{1}, nil}) {
+		t.Errorf("%v != %v", h, graph.Graph{{1}, nil})
+	}
+	if !reflect.DeepEqual(comp, map[T]int{"a": 0, "b": 0, "c": 0, "d": 1}) {
+		t.Errorf("%v != %v", comp, map[T]int{"a": 0, "b": 0, "c": 0, "d": 1})
+	}
+	// Reuse memory
+	comp["e"] = 2
+	if h, comp = g.Condensation(h, comp, w); len(comp) != 4 || len(h) != 2 {
+		t.Errorf("%v != %v", comp, map[T]int{"a": 0, "b": 0, "c": 0, "d": 1})
+	}
+
+	if tsort := g.TopologicalSort(nil, w); len(tsort) != 0 {
+		t.Errorf("%v != %v", tsort, []T{})
+	}
+	if _, cycle := g.TopologicalSortOrCycle(nil, nil, w); !reflect.DeepEqual(cycle, []T{"a", "b", "c", "a"}) {
+		t.Errorf("%v != %v", cycle, []T{"a", "b", "c", "a"})
+	}
+	// Reuse memory; no allocations beyond those of the underlying graph
+	tsort, cycle := g.TopologicalSortOrCycle(nil, nil, w)
+	vs, c := g.Graph().TopologicalSortOrCycle(nil, nil, w)
+	n := testing.AllocsPerRun(10, func() { tsort, cycle = g.TopologicalSortOrCycle(tsort, cycle, w) })
+	m := testing.AllocsPerRun(10, func() { vs, c = g.Graph().TopologicalSortOrCycle(vs, c, w) })
+	if n != m {
+		t.Errorf("%v != %v", n, m)
+	}
+
+	scc := g.StronglyConnectedComponents(nil, w)
+	if !reflect.DeepEqual(scc, [][]T{{"d"}, {"b", "c", "a"}}) {
+		t.Errorf("%v != %v", scc, [][]T{{"d"}, {"b", "c", "a"}})
+	}
+	// Reuse memory
+	if scc = g.StronglyConnectedComponents(scc, w); len(scc) != 2 {
+		t.Errorf("%v != %v", len(scc), 2)
+	}
+
+	if wcc := g.WeaklyConnectedComponents(nil, w); !reflect.DeepEqual(wcc, [][]T{{"a", "b", "c", "d"}}) {
+		t.Errorf("%v != %v", wcc, [][]T{{"a", "b", "c", "d"}})
+	}
+
+	tr := g.Transpose()
+	if path := tr.LeastEdgesPath(nil, "d", "b", w); !reflect.DeepEqual(path, []T{"d", "c", "b"}) {
+		t.Errorf("%v != %v", path, []T{"d", "c", "b"})
+	}
+
+	// Undirected path x - y - z
+	u := NewGenericTypeGraph(3)
+	for _, e := range [][2]T{{"x", "y"}, {"y", "z"}} {
+		u.AddEdge(e[0], e[1])
+		u.AddEdge(e[1], e[0])
+	}
+
+	if points := u.ArticulationPoints(nil, w); !reflect.DeepEqual(points, []T{"y"}) {
+		t.Errorf("%v != %v", points, []T{"y"})
+	}
+	if bridges := u.Bridges(nil, w); !reflect.DeepEqual(bridges, [][2]T{{"y", "z"}, {"x", "y"}}) {
+		t.Errorf("%v != %v", bridges, [][2]T{{"y", "z"}, {"x", "y"}})
+	}
+
+	// Close the triangle x - y - z with a heavy edge
+	u.AddEdge("x", "z")
+	u.AddEdge("z", "x")
+	uweights := [][]float64{{1, 9}, {1, 2}, {2, 9}}
+
+	if forest, total := u.KruskalForest(nil, uweights, w); len(forest) != 2 || total != 3 {
+		t.Errorf("%v, %v != %v, %v", forest, total, [][2]T{{"x", "y"}, {"y", "z"}}, 3)
+	}
+	if forest, total := u.PrimForest(nil, uweights, w); len(forest) != 2 || total != 3 {
+		t.Errorf("%v, %v != %v, %v", forest, total, [][2]T{{"x", "y"}, {"y", "z"}}, 3)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+// Package template provides the generic templates of package labeled via
+// https://github.com/cheekybits/genny/generic
+//
+// The templates are kept here, rather than in package generic, because they
+// depend on package graph, which itself depends on package generic/impl.
+package template
+
+import "github.com/cheekybits/genny/generic"
+
+type GenericType generic.Type
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"github.com/guns/golibs/bitslice"
+	"github.com/guns/golibs/generic/impl"
+)
+
+// BipartiteMatching returns a maximum matching of a bipartite graph, along
+// with the number of matched pairs.
+//
+// The left set of vertices is specified by the bitslice left, which must have
+// a capacity of at least len(g) bits, e.g. bitslice.Make(len(g)). All other
+// vertices are in the right set. Only edges from the left set to the right
+// set are considered; i.e. each candidate pair (u, v) must be present as an
+// edge from u to v.
+//
+// The matching is written to the mate slice, which is grown if necessary:
+// mate[u] == v and mate[v] == u for every matched pair, and mate[x] == -1 for
+// every unmatched vertex x.
+func (g Graph) BipartiteMatching(mate []int, left bitslice.T, w *Workspace) ([]int, int) {
+	n := len(g)
+	w.prepare(n, 0)
+
+	// Hopcroft-Karp
+
+	dist := w.a // |V|w · Slice of left vertex -> BFS layer
+	arc := w.c  // |V|w · Slice of left vertex -> index of next edge to try
+
+	mate = resizeIntSlice(mate, n)
+	for i := range mate {
+		mate[i] = undefined
+	}
+
+	size := 0
+
+	for {
+		// BFS from all free left vertices, alternating between unmatched
+		// and matched edges, to find the length of a shortest augmenting
+		// path.
+		queue := w.makeQueue(wB) // |V|w · BFS queue
+		limit := undefined
+
+		for u := 0; u < n; u++ {
+			dist[u] = undefined
+			if left.Get(u) && mate[u] == undefined {
+				dist[u] = 0
+				queue.Enqueue(u)
+			}
+		}
+
+		for queue.Len() > 0 {
+			u := queue.Dequeue()
+
+			if limit != undefined && dist[u] >= limit {
+				continue
+			}
+
+			for _, v := range g[u] {
+				if left.Get(v) {
+					continue
+				}
+
+				if x := mate[v]; x == undefined {
+					// Free right vertex
+					limit = dist[u] + 1
+				} else if dist[x] == undefined {
+					dist[x] = dist[u] + 1
+					queue.Enqueue(x)
+				}
+			}
+		}
+
+		if limit == undefined {
+			// No augmenting path
+			return mate, size
+		}
+
+		// DFS from every free left vertex for a maximal set of shortest
+		// augmenting paths.
+		for i := range arc {
+			arc[i] = 0
+		}
+
+		for u := 0; u < n; u++ {
+			if left.Get(u) && mate[u] == undefined && dist[u] == 0 {
+				if augmentMatching(g, mate, left, dist, arc, w.makeStack(wB), u, limit) {
+					size++
+				}
+			}
+		}
+	}
+}
+
+// augmentMatching finds an augmenting path from the free left vertex u along
+// the BFS layers in dist with an iterative DFS, and flips the matched status
+// of every edge on the path. Returns false if no augmenting path was found.
+//
+// Only left vertices are pushed on the stack. The edge taken from each left
+// vertex x is g[x][arc[x]], and dead-end vertices are removed from the layers.
+func augmentMatching(g Graph, mate []int, left bitslice.T, dist, arc []int, stack impl.IntStack, u, limit int) bool {
+	stack.Push(u)
+
+	for stack.Len() > 0 {
+		x := stack.Peek()
+		advanced := false
+
+		for ; arc[x] < len(g[x]); arc[x]++ {
+			v := g[x][arc[x]]
+			if left.Get(v) {
+				continue
+			}
+
+			if y := mate[v]; y == undefined {
+				if dist[x]+1 == limit {
+					// Augment along the stack
+					for stack.Len() > 0 {
+						x := stack.Pop()
+						v := g[x][arc[x]]
+						mate[x] = v
+						mate[v] = x
+					}
+					return true
+				}
+			} else if dist[y] == dist[x]+1 {
+				stack.Push(y)
+				advanced = true
+				break
+			}
+		}
+
+		if !advanced {
+			// Dead end; retreat and skip the edge into x
+			dist[x] = undefined
+			stack.Pop()
+			if stack.Len() > 0 {
+				arc[stack.Peek()]++
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+func TestGraphBipartiteMatching(t *testing.T) {
+	data := []struct {
+		size int
+		left []int
+		adj  map[int][]int
+		mate []int
+		n    int
+	}{
+		{
+			size: 0,
+			mate: []int{},
+		},
+		// Greedy matching of 0-3 must be undone
+		{
+			size: 4,
+			left: []int{0, 1},
+			adj: map[int][]int{
+				0: {3, 2},
+				1: {3},
+			},
+			mate: []int{2, 3, 0, 1},
+			n:    2,
+		},
+		// Edges within a set and from right to left are ignored
+		{
+			size: 4,
+			left: []int{0, 1},
+			adj: map[int][]int{
+				0: {1},
+				2: {0},
+				3: {1},
+			},
+			mate: []int{-1, -1, -1, -1},
+			n:    0,
+		},
+		// Interleaved sets
+		{
+			size: 6,
+			left: []int{1, 3, 5},
+			adj: map[int][]int{
+				1: {0, 2},
+				3: {2},
+				5: {2, 4},
+			},
+			mate: []int{1, 0, 3, 2, 5, 4},
+			n:    3,
+		},
+		// The last left vertex is beyond the first word of left
+		{
+			size: 65,
+			left: []int{0, 64},
+			adj: map[int][]int{
+				0:  {1},
+				64: {1, 63},
+			},
+			mate: func() []int {
+				mate := make([]int, 65)
+				for i := range mate {
+					mate[i] = -1
+				}
+				mate[0], mate[1] = 1, 0
+				mate[64], mate[63] = 63, 64
+				return mate
+			}(),
+			n: 2,
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g := make(Graph, row.size)
+		left := bitslice.Make(len(g))
+
+		for _, u := range row.left {
+			left.Set(u)
+		}
+		for u, edges := range row.adj {
+			for _, v := range edges {
+				g.AddEdge(u, v)
+			}
+		}
+
+		mate, n := g.BipartiteMatching([]int{}, left, w)
+
+		if !reflect.DeepEqual(mate, row.mate) {
+			t.Errorf("%v != %v", mate, row.mate)
+		}
+		if n != row.n {
+			t.Errorf("%v != %v", n, row.n)
+		}
+	}
+}
+
+func TestGraphBipartiteMatchingRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+	var mate []int
+
+	for trial := 0; trial < 100; trial++ {
+		size := 2 + r.Intn(20)
+		g := make(Graph, size)
+		left := bitslice.Make(size)
+
+		for u := 0; u < size; u++ {
+			if r.Intn(2) == 0 {
+				left.Set(u)
+			}
+		}
+		for i := 0; i < size*2; i++ {
+			u, v := r.Intn(size), r.Intn(size)
+			if left.Get(u) && !left.Get(v) {
+				g.AddEdge(u, v)
+			}
+		}
+
+		var n int
+		mate, n = g.BipartiteMatching(mate, left, w)
+
+		// Check consistency
+		pairs := 0
+		for u, v := range mate {
+			if v == undefined {
+				continue
+			}
+			if mate[v] != u {
+				t.Fatalf("mate[%v] = %v, but mate[%v] = %v", u, v, v, mate[v])
+			}
+			if left.Get(u) {
+				pairs++
+				found := false
+				for _, x := range g[u] {
+					found = found || x == v
+				}
+				if !found {
+					t.Errorf("(%v,%v) is not an edge", u, v)
+				}
+			}
+		}
+		if pairs != n {
+			t.Errorf("%v != %v", pairs, n)
+		}
+
+		if k := kuhnMatching(g, left); k != n {
+			t.Errorf("%v != %v", n, k)
+		}
+	}
+}
+
+// kuhnMatching returns the size of a maximum matching with the simple
+// augmenting path algorithm.
+func kuhnMatching(g Graph, left bitslice.T) int {
+	mate := make([]int, len(g))
+	for i := range mate {
+		mate[i] = undefined
+	}
+
+	var try func(u int, seen []bool) bool
+	try = func(u int, seen []bool) bool {
+		for _, v := range g[u] {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			if mate[v] == undefined || try(mate[v], seen) {
+				mate[v] = u
+				return true
+			}
+		}
+		return false
+	}
+
+	n := 0
+	for u := range g {
+		if left.Get(u) && try(u, make([]bool, len(g))) {
+			n++
+		}
+	}
+
+	return n
+}
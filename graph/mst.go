@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import "sort"
+
+// KruskalForest returns a minimum spanning forest of an undirected graph,
+// along with its total weight. Edge weights are read from weights, which
+// must be parallel to g.
+//
+// An undirected graph is represented by adding both (u, v) and (v, u) for
+// every edge, but only one direction of each chosen edge is returned. Self
+// edges are never chosen.
+//
+// The edges of the forest are written to forest in order of increasing
+// weight, and forest is grown if necessary. Note that this function
+// allocates a sorted copy of all |E| edges; PrimForest does not.
+func (g Graph) KruskalForest(forest []Edge, weights [][]float64, w *Workspace) ([]Edge, float64) {
+	w.prepare(len(g), 0)
+
+	uf := w.makeUnionFind(wA | wB) // 2|V|w · Union-find of vertex -> component
+
+	m := 0
+	for u := range g {
+		m += len(g[u])
+	}
+
+	edges := make([]Edge, 0, m)
+	for u := range g {
+		for i, v := range g[u] {
+			if u != v {
+				edges = append(edges, Edge{U: u, V: v, I: i})
+			}
+		}
+	}
+
+	sort.Stable(edgesByWeight{edges, weights})
+
+	forest = forest[:0]
+	total := 0.0
+
+	for _, e := range edges {
+		if uf.Union(e.U, e.V) {
+			forest = append(forest, e)
+			total += weights[e.U][e.I]
+		}
+	}
+
+	return forest, total
+}
+
+// PrimForest is like KruskalForest, but uses Prim's algorithm with an
+// indexed binary heap, which does not require sorting all edges. The edges
+// of the forest are written in order of their endpoint V.
+func (g Graph) PrimForest(forest []Edge, weights [][]float64, w *Workspace) ([]Edge, float64) {
+	n := len(g)
+	w.prepare(n, wC|wFInf)
+
+	key := w.f                  // |V|f  · Slice of vertex -> weight of cheapest edge to tree
+	heap := w.makeHeap(wA | wB) // 2|V|w · Priority queue of vertices keyed by key
+	settled := w.c              // |V|w  · Slice of vertex -> in tree?
+
+	// Until the end, forest[v] is the cheapest edge from the tree to v.
+	if cap(forest) >= n {
+		forest = forest[:n]
+	} else {
+		forest = make([]Edge, n)
+	}
+	for v := range forest {
+		forest[v] = Edge{U: undefined, V: v, I: undefined}
+	}
+
+	total := 0.0
+
+	for root := range g {
+		if settled[root] != 0 {
+			continue
+		}
+
+		key[root] = 0
+		heap.update(root)
+
+		for heap.len > 0 {
+			u := heap.pop()
+			settled[u] = 1
+			total += key[u]
+
+			for i, v := range g[u] {
+				if settled[v] == 0 && weights[u][i] < key[v] {
+					key[v] = weights[u][i]
+					forest[v] = Edge{U: u, V: v, I: i}
+					heap.update(v)
+				}
+			}
+		}
+	}
+
+	// Remove the roots
+	k := 0
+	for _, e := range forest {
+		if e.U != undefined {
+			forest[k] = e
+			k++
+		}
+	}
+
+	return forest[:k], total
+}
+
+// edgesByWeight implements sort.Interface for a slice of Edge.
+type edgesByWeight struct {
+	edges   []Edge
+	weights [][]float64
+}
+
+func (s edgesByWeight) Len() int      { return len(s.edges) }
+func (s edgesByWeight) Swap(i, j int) { s.edges[i], s.edges[j] = s.edges[j], s.edges[i] }
+func (s edgesByWeight) Less(i, j int) bool {
+	a, b := s.edges[i], s.edges[j]
+	return s.weights[a.U][a.I] < s.weights[b.U][b.I]
+}
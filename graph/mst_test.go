@@ -0,0 +1,123 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// makeUndirectedWeightedGraph returns a symmetric graph with both directions
+// of every edge.
+func makeUndirectedWeightedGraph(size int, edges []weightedEdge) (Graph, [][]float64) {
+	sym := make([]weightedEdge, 0, len(edges)*2)
+
+	for _, e := range edges {
+		sym = append(sym, e, weightedEdge{e.v, e.u, e.w})
+	}
+
+	return makeWeightedGraph(size, sym)
+}
+
+func checkForest(t *testing.T, g Graph, weights [][]float64, forest []Edge, total float64) {
+	uf := NewWorkspace(len(g)).makeUnionFind(wA | wB)
+	sum := 0.0
+
+	for _, e := range forest {
+		if g[e.U][e.I] != e.V {
+			t.Errorf("%v is not an edge", e)
+		}
+		if !uf.Union(e.U, e.V) {
+			t.Errorf("%v closes a cycle", e)
+		}
+		sum += weights[e.U][e.I]
+	}
+
+	if sum != total {
+		t.Errorf("%v != %v", sum, total)
+	}
+}
+
+func TestGraphMinimumSpanningForest(t *testing.T) {
+	type E = weightedEdge
+
+	data := []struct {
+		size  int
+		edges []E
+		n     int
+		total float64
+	}{
+		{size: 0, edges: []E{}, n: 0, total: 0},
+		{size: 1, edges: []E{{0, 0, 1}}, n: 0, total: 0},
+		{size: 2, edges: []E{}, n: 0, total: 0},
+		{size: 2, edges: []E{{0, 1, 3}, {0, 1, 2}}, n: 1, total: 2},
+		{
+			size: 4,
+			edges: []E{
+				{0, 1, 1}, {1, 2, 2}, {2, 3, 3}, {3, 0, 4}, {0, 2, 5},
+			},
+			n:     3,
+			total: 6,
+		},
+		{
+			// Two components
+			size: 6,
+			edges: []E{
+				{0, 1, 4}, {1, 2, -1}, {0, 2, 2},
+				{3, 4, 1}, {4, 5, 1}, {3, 5, 1},
+			},
+			n:     4,
+			total: 3,
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g, weights := makeUndirectedWeightedGraph(row.size, row.edges)
+
+		for _, mst := range []func([]Edge, [][]float64, *Workspace) ([]Edge, float64){g.KruskalForest, g.PrimForest} {
+			forest, total := mst(nil, weights, w)
+
+			if len(forest) != row.n {
+				t.Errorf("%v != %v", len(forest), row.n)
+			}
+			if total != row.total {
+				t.Errorf("%v != %v", total, row.total)
+			}
+
+			checkForest(t, g, weights, forest, total)
+		}
+	}
+}
+
+func TestGraphMinimumSpanningForestRandom(t *testing.T) {
+	w := NewWorkspace(0)
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		size := r.Intn(40) + 1
+		edges := make([]weightedEdge, r.Intn(size*3))
+
+		for j := range edges {
+			edges[j] = weightedEdge{r.Intn(size), r.Intn(size), float64(r.Intn(20))}
+		}
+
+		g, weights := makeUndirectedWeightedGraph(size, edges)
+
+		kforest, ktotal := g.KruskalForest(nil, weights, w)
+		pforest, ptotal := g.PrimForest(nil, weights, w)
+
+		if ktotal != ptotal {
+			t.Errorf("%v != %v", ktotal, ptotal)
+		}
+		if len(kforest) != len(pforest) {
+			t.Errorf("%v != %v", len(kforest), len(pforest))
+		}
+
+		checkForest(t, g, weights, kforest, ktotal)
+		checkForest(t, g, weights, pforest, ptotal)
+	}
+}
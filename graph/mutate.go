@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"sort"
+
+	"github.com/guns/golibs/bitslice"
+	"github.com/guns/golibs/generic/impl"
+)
+
+// Note that the following methods change the order or indices of edges, so
+// parallel data structures such as edge weights must be updated separately.
+
+// AddVertex adds a single vertex without edges to the graph. Returns the
+// graph, which may be reallocated, and the index of the new vertex.
+func (g Graph) AddVertex() (Graph, int) {
+	return append(g, nil), len(g)
+}
+
+// HasEdge returns true if there is an edge from vertex u to v.
+func (g Graph) HasEdge(u, v int) bool {
+	for _, x := range g[u] {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SortEdges sorts the edges of every vertex by head. This enables
+// HasEdgeSorted, and fixes the order of the edges of each vertex regardless
+// of the order in which they were added.
+func (g Graph) SortEdges() {
+	for u := range g {
+		impl.QuicksortIntSlice(g[u])
+	}
+}
+
+// HasEdgeSorted is like HasEdge, but uses a binary search, so the edges of
+// vertex u must be sorted; e.g. with SortEdges.
+func (g Graph) HasEdgeSorted(u, v int) bool {
+	edges := g[u]
+	i := sort.SearchInts(edges, v)
+
+	return i < len(edges) && edges[i] == v
+}
+
+// RemoveEdge removes the first edge from vertex u to v, and returns true if
+// such an edge existed. The order of the remaining edges of u is preserved,
+// so sorted edges remain sorted.
+func (g Graph) RemoveEdge(u, v int) bool {
+	for i, x := range g[u] {
+		if x == v {
+			g[u] = append(g[u][:i], g[u][i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveParallelEdges removes every edge (u, v) that is preceded by another
+// edge (u, v) in g[u], so that each vertex has at most one edge to any other
+// vertex. The order of the remaining edges is preserved. Returns the number
+// of removed edges.
+func (g Graph) RemoveParallelEdges(w *Workspace) int {
+	w.prepare(len(g), wANeg)
+
+	seen := w.a // |V|w · Slice of vertex -> last tail with an edge to vertex
+
+	removed := 0
+
+	for u := range g {
+		edges := g[u]
+		n := 0
+
+		for _, v := range edges {
+			if seen[v] == u {
+				continue
+			}
+			seen[v] = u
+			edges[n] = v
+			n++
+		}
+
+		removed += len(edges) - n
+		g[u] = edges[:n]
+	}
+
+	return removed
+}
+
+// RemoveVertices removes every vertex in the bitslice remove, which must have
+// a capacity of at least len(g) bits, along with all edges into and out of
+// those vertices. The remaining vertices are renumbered in order to fill the
+// gaps, and the edges of each remaining vertex keep their order.
+//
+// The graph is compacted in place and returned. The mapping of old vertex
+// indices to new vertex indices is written to remap, which is grown if
+// necessary: remap[v] is the new index of v, or -1 if v was removed.
+func (g Graph) RemoveVertices(remove bitslice.T, remap []int) (Graph, []int) {
+	remap = resizeIntSlice(remap, len(g))
+
+	n := 0
+	for v := range g {
+		if remove.Get(v) {
+			remap[v] = undefined
+		} else {
+			remap[v] = n
+			n++
+		}
+	}
+
+	for u := range g {
+		if remap[u] == undefined {
+			continue
+		}
+
+		edges := g[u]
+		k := 0
+
+		for _, v := range edges {
+			if x := remap[v]; x != undefined {
+				edges[k] = x
+				k++
+			}
+		}
+
+		g[remap[u]] = edges[:k]
+	}
+
+	// Release the edges of removed vertices
+	for i := n; i < len(g); i++ {
+		g[i] = nil
+	}
+
+	return g[:n], remap
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/guns/golibs/bitslice"
+)
+
+func TestGraphAddVertex(t *testing.T) {
+	var g Graph
+
+	g, u := g.AddVertex()
+	g, v := g.AddVertex()
+	g.AddEdge(u, v)
+
+	if !reflect.DeepEqual(g, Graph{{1}, nil}) {
+		t.Errorf("%v != %v", g, Graph{{1}, nil})
+	}
+}
+
+func TestGraphRemoveEdge(t *testing.T) {
+	g := Graph{{1, 2, 1, 0}, {}, {}}
+
+	data := []struct {
+		u, v    int
+		removed bool
+		edges   []int
+	}{
+		{u: 0, v: 1, removed: true, edges: []int{2, 1, 0}},
+		{u: 0, v: 1, removed: true, edges: []int{2, 0}},
+		{u: 0, v: 1, removed: false, edges: []int{2, 0}},
+		{u: 0, v: 0, removed: true, edges: []int{2}},
+		{u: 1, v: 0, removed: false, edges: []int{}},
+	}
+
+	for _, row := range data {
+		if removed := g.RemoveEdge(row.u, row.v); removed != row.removed {
+			t.Errorf("%v != %v", removed, row.removed)
+		}
+		if !reflect.DeepEqual(g[row.u], row.edges) {
+			t.Errorf("%v != %v", g[row.u], row.edges)
+		}
+
+		has := false
+		for _, v := range row.edges {
+			if v == row.v {
+				has = true
+			}
+		}
+		if g.HasEdge(row.u, row.v) != has {
+			t.Errorf("%v != %v", g.HasEdge(row.u, row.v), has)
+		}
+	}
+}
+
+func TestGraphRemoveParallelEdges(t *testing.T) {
+	g := Graph{{3, 1, 3, 3, 0, 1}, {}, {2, 2}, {0}}
+	w := NewWorkspace(0)
+
+	if n := g.RemoveParallelEdges(w); n != 4 {
+		t.Errorf("%v != %v", n, 4)
+	}
+
+	out := Graph{{3, 1, 0}, {}, {2}, {0}}
+	if !reflect.DeepEqual(g, out) {
+		t.Errorf("%v != %v", g, out)
+	}
+}
+
+func TestGraphRemoveVertices(t *testing.T) {
+	data := []struct {
+		g      Graph
+		remove []int
+		out    Graph
+		remap  []int
+	}{
+		{g: Graph{}, remove: []int{}, out: Graph{}, remap: []int{}},
+		{g: Graph{{0}}, remove: []int{0}, out: Graph{}, remap: []int{-1}},
+		{
+			g:      Graph{{1, 2, 3}, {0, 3}, {2, 1}, {0}},
+			remove: []int{1},
+			out:    Graph{{1, 2}, {1}, {0}},
+			remap:  []int{0, -1, 1, 2},
+		},
+		{
+			g:      Graph{{1, 2, 3}, {0, 3}, {2, 1}, {0}},
+			remove: []int{0, 2},
+			out:    Graph{{1}, {}},
+			remap:  []int{-1, 0, -1, 1},
+		},
+	}
+
+	for _, row := range data {
+		remove := bitslice.Make(len(row.g))
+		for _, v := range row.remove {
+			remove.Set(v)
+		}
+
+		g, remap := row.g.RemoveVertices(remove, nil)
+
+		if !reflect.DeepEqual(g, row.out) {
+			t.Errorf("%v != %v", g, row.out)
+		}
+		if !reflect.DeepEqual(remap, row.remap) && len(remap)+len(row.remap) > 0 {
+			t.Errorf("%v != %v", remap, row.remap)
+		}
+	}
+}
+
+func TestGraphHasEdgeSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 20; trial++ {
+		size := r.Intn(50) + 1
+		g := make(Graph, size)
+		for i := 0; i < size*3; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+
+		h := g.Transpose(nil).Transpose(nil) // Copy
+		h.SortEdges()
+
+		for u := range h {
+			for i := 1; i < len(h[u]); i++ {
+				if h[u][i-1] > h[u][i] {
+					t.Errorf("%v is not sorted", h[u])
+				}
+			}
+			for v := range h {
+				if g.HasEdge(u, v) != h.HasEdgeSorted(u, v) {
+					t.Errorf("%v != %v", g.HasEdge(u, v), h.HasEdgeSorted(u, v))
+				}
+			}
+		}
+	}
+}
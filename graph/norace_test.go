@@ -0,0 +1,10 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+//go:build !race
+// +build !race
+
+package graph
+
+const raceEnabled = false
@@ -0,0 +1,79 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// A WorkspacePool is a set of reusable Workspaces that is safe for concurrent
+// use. A Workspace is still single-goroutine scratch space, but each caller
+// can Get its own Workspace, run any number of algorithms with it, and Put it
+// back when done.
+//
+// Workspaces are grouped into size classes by capacity, so a Workspace that
+// is large enough for a graph is found without scanning. Idle Workspaces may
+// be released by the garbage collector at any time, as with sync.Pool.
+//
+// The zero value is an empty pool that does not clear returned Workspaces.
+type WorkspacePool struct {
+	classes [bits.UintSize]sync.Pool // Size class -> Workspaces with a capacity of at least 1<<class
+	clear   bool
+}
+
+// NewWorkspacePool returns a new empty WorkspacePool. If clear is true, the
+// memory of every Workspace is zeroed when it is returned to the pool, so
+// that data derived from a graph does not outlive the algorithms that use it.
+func NewWorkspacePool(clear bool) *WorkspacePool {
+	return &WorkspacePool{clear: clear}
+}
+
+// Get returns a Workspace for a graph of a given size from the pool, or a new
+// Workspace if there is none available. The returned Workspace is resized to
+// size, and has a capacity of at least size rounded up to a power of two.
+func (p *WorkspacePool) Get(size int) *Workspace {
+	class := sizeClass(size)
+
+	if w, ok := p.classes[class].Get().(*Workspace); ok {
+		w.resize(size)
+		return w
+	}
+
+	w := NewWorkspace(1 << uint(class))
+	w.resize(size)
+
+	return w
+}
+
+// Put returns a Workspace to the pool. The Workspace must not be used after
+// it is returned. Workspaces that grew while they were in use are returned
+// to a larger size class.
+func (p *WorkspacePool) Put(w *Workspace) {
+	if w.cap == 0 {
+		return
+	}
+
+	if p.clear {
+		w.clear()
+	}
+
+	p.classes[capClass(w.cap)].Put(w)
+}
+
+// sizeClass returns the smallest size class whose Workspaces can serve a
+// graph of a given size.
+func sizeClass(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
+
+// capClass returns the largest size class that a Workspace with a given
+// positive capacity can serve.
+func capClass(cap int) int {
+	return bits.Len(uint(cap)) - 1
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWorkspacePool(t *testing.T) {
+	p := NewWorkspacePool(true)
+
+	for _, size := range []int{0, 1, 2, 3, 4, 5, 100, 1 << 10} {
+		w := p.Get(size)
+
+		if w.len != size {
+			t.Errorf("%v != %v", w.len, size)
+		}
+		if w.cap < size || w.cap&(w.cap-1) != 0 {
+			t.Errorf("invalid capacity %v for size %v", w.cap, size)
+		}
+
+		p.Put(w)
+	}
+
+	// Workspaces are cleared when they are returned
+	dirty := func(w *Workspace) {
+		w.prepare(8, wFInf|wEInf|wDNeg)
+		for _, buf := range [][]int{w.a, w.b, w.c} {
+			for i := range buf {
+				buf[i] = 1
+			}
+		}
+		w.resize(4) // Memory beyond the current size must also be cleared
+	}
+
+	isClear := func(w *Workspace) bool {
+		for _, buf := range [][]int{w.a[:w.cap], w.b[:w.cap], w.c[:w.cap], w.d[:cap(w.d)]} {
+			for i := range buf {
+				if buf[i] != 0 {
+					return false
+				}
+			}
+		}
+		for _, buf := range [][]float64{w.f[:cap(w.f)], w.e[:cap(w.e)]} {
+			for i := range buf {
+				if buf[i] != 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	w := NewWorkspace(8)
+
+	dirty(w)
+	if isClear(w) {
+		t.Errorf("%v != %v", true, false)
+	}
+	if w.clear(); !isClear(w) {
+		t.Errorf("%v != %v", false, true)
+	}
+
+	dirty(w)
+	if p.Put(w); !isClear(w) {
+		t.Errorf("%v != %v", false, true)
+	}
+
+	w = NewWorkspace(8)
+	dirty(w)
+	if NewWorkspacePool(false).Put(w); isClear(w) {
+		t.Errorf("%v != %v", true, false)
+	}
+}
+
+func TestWorkspacePoolClasses(t *testing.T) {
+	data := []struct {
+		n         int
+		sizeClass int // Smallest class that can serve a graph of size n
+		capClass  int // Largest class that a Workspace of capacity n can serve
+	}{
+		{n: 0, sizeClass: 0},
+		{n: 1, sizeClass: 0, capClass: 0},
+		{n: 2, sizeClass: 1, capClass: 1},
+		{n: 3, sizeClass: 2, capClass: 1},
+		{n: 4, sizeClass: 2, capClass: 2},
+		{n: 5, sizeClass: 3, capClass: 2},
+		{n: 9, sizeClass: 4, capClass: 3},
+		{n: 1 << 10, sizeClass: 10, capClass: 10},
+	}
+
+	for _, row := range data {
+		if c := sizeClass(row.n); c != row.sizeClass {
+			t.Errorf("sizeClass(%v): %v != %v", row.n, c, row.sizeClass)
+		}
+		if row.n > 0 {
+			if c := capClass(row.n); c != row.capClass {
+				t.Errorf("capClass(%v): %v != %v", row.n, c, row.capClass)
+			}
+		}
+
+		// A Workspace from the pool can serve its size class, and a
+		// Workspace in the class of its capacity can serve every size
+		// that maps to that class.
+		if cap := 1 << uint(sizeClass(row.n)); cap < row.n {
+			t.Errorf("class %v cannot serve size %v", sizeClass(row.n), row.n)
+		}
+		if row.n > 0 && 1<<uint(capClass(row.n)) > row.n {
+			t.Errorf("capacity %v cannot serve class %v", row.n, capClass(row.n))
+		}
+	}
+}
+
+func TestWorkspacePoolAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random with -race")
+	}
+
+	g := Graph{{1, 3}, {2}, {0, 3}, {}}
+	p := NewWorkspacePool(false)
+	path := make([]int, len(g))
+	scc := g.StronglyConnectedComponents(nil, NewWorkspace(len(g)))
+
+	// Warm the pool
+	p.Put(p.Get(len(g)))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		w := p.Get(len(g))
+		path = g.LeastEdgesPath(path, 0, 2, w)
+		p.Put(w)
+	})
+
+	if allocs != 0 {
+		t.Errorf("%v != %v", allocs, 0)
+	}
+
+	// No allocations beyond those of the algorithm itself
+	w := NewWorkspace(len(g))
+	want := testing.AllocsPerRun(10, func() {
+		scc = g.StronglyConnectedComponents(scc, w)
+	})
+
+	allocs = testing.AllocsPerRun(10, func() {
+		w := p.Get(len(g))
+		scc = g.StronglyConnectedComponents(scc, w)
+		p.Put(w)
+	})
+
+	if allocs != want {
+		t.Errorf("%v != %v", allocs, want)
+	}
+}
+
+func TestWorkspacePoolConcurrent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	graphs := make([]Graph, 8)
+
+	for i := range graphs {
+		size := r.Intn(200) + 1
+		g := make(Graph, size)
+		for j := 0; j < size*2; j++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+		graphs[i] = g
+	}
+
+	// Expected results
+	w := NewWorkspace(0)
+	scc := make([][][]int, len(graphs))
+	paths := make([][]int, len(graphs))
+	for i, g := range graphs {
+		scc[i] = g.StronglyConnectedComponents(nil, w)
+		paths[i] = g.LeastEdgesPath(nil, 0, len(g)-1, w)
+	}
+
+	p := NewWorkspacePool(false)
+	var wg sync.WaitGroup
+
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for k := 0; k < 50; k++ {
+				for i, g := range graphs {
+					w := p.Get(len(g))
+
+					if s := g.StronglyConnectedComponents(nil, w); !reflect.DeepEqual(s, scc[i]) {
+						t.Errorf("%v != %v", s, scc[i])
+					}
+					if path := g.LeastEdgesPath(nil, 0, len(g)-1, w); !reflect.DeepEqual(path, paths[i]) {
+						t.Errorf("%v != %v", path, paths[i])
+					}
+
+					p.Put(w)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
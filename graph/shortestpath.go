@@ -0,0 +1,233 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import "math"
+
+// ShortestPath returns a path from vertex u to v with a minimum total edge
+// weight, along with that total weight.
+//
+// Edge weights are read from weights, which must be parallel to g; i.e.
+// weights[u][i] is the weight of the edge (u, g[u][i]). All weights must be
+// non-negative. See BellmanFordPath for graphs with negative edge weights.
+//
+// The path is written to the path slice, which is grown if necessary.
+//
+// If no path exists, an empty slice and +Inf are returned.
+//
+// As with LeastEdgesPath, trivial paths are not considered.
+func (g Graph) ShortestPath(path []int, u, v int, weights [][]float64, w *Workspace) ([]int, float64) {
+	w.prepare(len(g), wANeg|wFInf)
+
+	// Dijkstra's algorithm with an indexed binary heap
+
+	pred := w.a                 // |V|w  · Slice of vertex -> predecessor vertex
+	dist := w.f                 // |V|f  · Slice of vertex -> weighted distance from u
+	heap := w.makeHeap(wB | wC) // 2|V|w · Priority queue of vertices keyed by dist
+
+	// If u == v, u is the endpoint, so leave its distance undefined.
+	target := v
+	if u != target {
+		dist[u] = 0
+	}
+
+	x, d := u, 0.0
+
+	for {
+		for i, y := range g[x] {
+			if alt := d + weights[x][i]; alt < dist[y] {
+				dist[y] = alt
+				pred[y] = x
+				heap.update(y)
+			}
+		}
+
+		if heap.len == 0 {
+			break
+		}
+
+		x = heap.pop()
+		d = dist[x]
+
+		if x == target {
+			break
+		}
+	}
+
+	if pred[target] == undefined {
+		// No path from u -> v was discovered
+		return path[:0], math.Inf(1)
+	}
+
+	return writePath(path, pred, target, predPathLen(pred, u, target)), dist[target]
+}
+
+// ShortestPathInt is like ShortestPath, but reads integer edge weights from
+// weights, which must be parallel to g. Distances are computed exactly with
+// integer arithmetic, so the total weight of every path must fit in an int.
+// All weights must be non-negative.
+//
+// If no path exists, an empty slice and -1 are returned.
+func (g Graph) ShortestPathInt(path []int, u, v int, weights [][]int, w *Workspace) ([]int, int) {
+	w.prepare(len(g), wANeg|wDNeg)
+
+	pred := w.a                    // |V|w  · Slice of vertex -> predecessor vertex
+	dist := w.d                    // |V|w  · Slice of vertex -> weighted distance from u, or undefined
+	heap := w.makeIntHeap(wB | wC) // 2|V|w · Priority queue of vertices keyed by dist
+
+	// If u == v, u is the endpoint, so leave its distance undefined.
+	target := v
+	if u != target {
+		dist[u] = 0
+	}
+
+	x, d := u, 0
+
+	for {
+		for i, y := range g[x] {
+			if alt := d + weights[x][i]; dist[y] == undefined || alt < dist[y] {
+				dist[y] = alt
+				pred[y] = x
+				heap.update(y)
+			}
+		}
+
+		if heap.len == 0 {
+			break
+		}
+
+		x = heap.pop()
+		d = dist[x]
+
+		if x == target {
+			break
+		}
+	}
+
+	if pred[target] == undefined {
+		// No path from u -> v was discovered
+		return path[:0], undefined
+	}
+
+	return writePath(path, pred, target, predPathLen(pred, u, target)), dist[target]
+}
+
+// predPathLen returns the number of edges on the path from u to v encoded in
+// the predecessor slice pred.
+func predPathLen(pred []int, u, v int) int {
+	n := 1
+
+	for x := pred[v]; x != u; x = pred[x] {
+		n++
+	}
+
+	return n
+}
+
+// BellmanFordPath returns a path from vertex u to v with a minimum total edge
+// weight, along with that total weight. Unlike ShortestPath, edge weights may
+// be negative. Weights are read from weights, which must be parallel to g.
+//
+// If a cycle with a negative total weight is reachable from u, shortest paths
+// are undefined. In this case, the vertices of one such cycle are written to
+// path as a closed walk (the first and last vertices are equal), the total
+// weight of the cycle is returned, and negCycle is true.
+//
+// The path is written to the path slice, which is grown if necessary.
+//
+// If no path exists, an empty slice and +Inf are returned.
+//
+// As with LeastEdgesPath, trivial paths are not considered.
+func (g Graph) BellmanFordPath(path []int, u, v int, weights [][]float64, w *Workspace) (p []int, dist float64, negCycle bool) {
+	w.prepare(len(g), wANeg|wFInf)
+
+	pred := w.a // |V|w · Slice of vertex -> predecessor vertex
+	d := w.f    // |V|f · Slice of vertex -> weighted distance from u
+
+	// If u == v, u is the endpoint, so leave its distance undefined and
+	// relax its edges in advance.
+	target := v
+	if u != target {
+		d[u] = 0
+	}
+
+	for i, y := range g[u] {
+		if weights[u][i] < d[y] {
+			d[y] = weights[u][i]
+			pred[y] = u
+		}
+	}
+
+	// Every shortest path has at most |V| edges, and the first edge of each
+	// has already been relaxed, so at most |V|-1 passes remain. Each pass
+	// relaxes every edge and stops early if no distance changes.
+	changed := true
+	for pass := 1; pass < len(g) && changed; pass++ {
+		changed = g.relaxAll(pred, d, weights) != undefined
+	}
+
+	if changed {
+		// Relaxing an edge on the |V|th pass proves the existence of a
+		// negative cycle. Walking back |V| times from the relaxed vertex
+		// lands on the cycle.
+		if x := g.relaxAll(pred, d, weights); x != undefined {
+			for i := 0; i < len(g); i++ {
+				x = pred[x]
+			}
+
+			n := predPathLen(pred, x, x)
+			p = writePath(path, pred, x, n)
+
+			sum := 0.0
+			for i := 0; i < n; i++ {
+				sum += edgeWeight(g, weights, p[i], p[i+1])
+			}
+
+			return p, sum, true
+		}
+	}
+
+	if pred[target] == undefined {
+		// No path from u -> v was discovered
+		return path[:0], math.Inf(1), false
+	}
+
+	return writePath(path, pred, target, predPathLen(pred, u, target)), d[target], false
+}
+
+// relaxAll relaxes every edge of g once and returns the last vertex whose
+// distance was decreased, or undefined if no distance changed.
+func (g Graph) relaxAll(pred []int, dist []float64, weights [][]float64) int {
+	last := undefined
+
+	for x := range g {
+		if math.IsInf(dist[x], 1) {
+			continue
+		}
+
+		for i, y := range g[x] {
+			if alt := dist[x] + weights[x][i]; alt < dist[y] {
+				dist[y] = alt
+				pred[y] = x
+				last = y
+			}
+		}
+	}
+
+	return last
+}
+
+// edgeWeight returns the minimum weight of the edges (u, v).
+func edgeWeight(g Graph, weights [][]float64, u, v int) float64 {
+	min := math.Inf(1)
+
+	for i, x := range g[u] {
+		if x == v && weights[u][i] < min {
+			min = weights[u][i]
+		}
+	}
+
+	return min
+}
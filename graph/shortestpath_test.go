@@ -0,0 +1,310 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type weightedEdge struct {
+	u, v int
+	w    float64
+}
+
+func makeWeightedGraph(size int, edges []weightedEdge) (Graph, [][]float64) {
+	g := make(Graph, size)
+	weights := make([][]float64, size)
+
+	for _, e := range edges {
+		g.AddEdge(e.u, e.v)
+		weights[e.u] = append(weights[e.u], e.w)
+	}
+
+	return g, weights
+}
+
+func TestGraphShortestPath(t *testing.T) {
+	type E = weightedEdge
+
+	data := []struct {
+		size  int
+		edges []E
+		u, v  int
+		path  []int
+		dist  float64
+	}{
+		// Fewer edges, but heavier
+		{
+			size:  4,
+			edges: []E{{0, 3, 10}, {0, 1, 1}, {1, 2, 1}, {2, 3, 1}},
+			u:     0,
+			v:     3,
+			path:  []int{0, 1, 2, 3},
+			dist:  3,
+		},
+		// CLRS Figure 24.6
+		{
+			size: 5,
+			edges: []E{
+				{0, 1, 10}, {0, 3, 5},
+				{1, 2, 1}, {1, 3, 2},
+				{2, 4, 4},
+				{3, 1, 3}, {3, 2, 9}, {3, 4, 2},
+				{4, 0, 7}, {4, 2, 6},
+			},
+			u:    0,
+			v:    2,
+			path: []int{0, 3, 1, 2},
+			dist: 9,
+		},
+		// Zero weight edges
+		{
+			size:  3,
+			edges: []E{{0, 1, 0}, {1, 2, 0}, {0, 2, 1}},
+			u:     0,
+			v:     2,
+			path:  []int{0, 1, 2},
+			dist:  0,
+		},
+		// No path
+		{
+			size:  4,
+			edges: []E{{0, 1, 1}, {1, 2, 1}, {3, 0, 1}},
+			u:     0,
+			v:     3,
+			path:  []int{},
+			dist:  math.Inf(1),
+		},
+		// Cycle
+		{
+			size:  3,
+			edges: []E{{0, 1, 1}, {1, 0, 5}, {1, 2, 1}, {2, 0, 1}},
+			u:     0,
+			v:     0,
+			path:  []int{0, 1, 2, 0},
+			dist:  3,
+		},
+		// Self-loop
+		{
+			size:  2,
+			edges: []E{{0, 0, 2}, {0, 1, 1}, {1, 0, 2}},
+			u:     0,
+			v:     0,
+			path:  []int{0, 0},
+			dist:  2,
+		},
+		// No cycle
+		{
+			size:  2,
+			edges: []E{{0, 1, 1}},
+			u:     0,
+			v:     0,
+			path:  []int{},
+			dist:  math.Inf(1),
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g, weights := makeWeightedGraph(row.size, row.edges)
+
+		path, dist := g.ShortestPath([]int{}, row.u, row.v, weights, w)
+
+		if !reflect.DeepEqual(path, row.path) {
+			t.Errorf("%v != %v", path, row.path)
+		}
+		if dist != row.dist {
+			t.Errorf("%v != %v", dist, row.dist)
+		}
+
+		// Integer weights
+		iweights := make([][]int, len(weights))
+		for u := range weights {
+			for _, x := range weights[u] {
+				iweights[u] = append(iweights[u], int(x))
+			}
+		}
+
+		idist := -1
+		if !math.IsInf(row.dist, 1) {
+			idist = int(row.dist)
+		}
+
+		path, d := g.ShortestPathInt(path, row.u, row.v, iweights, w)
+
+		if !reflect.DeepEqual(path, row.path) {
+			t.Errorf("%v != %v", path, row.path)
+		}
+		if d != idist {
+			t.Errorf("%v != %v", d, idist)
+		}
+	}
+}
+
+func TestGraphShortestPathAllocs(t *testing.T) {
+	g, weights := makeWeightedGraph(4, []weightedEdge{
+		{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {0, 3, 5},
+	})
+
+	w := NewWorkspace(len(g))
+	path := make([]int, len(g))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		path, _ = g.ShortestPath(path, 0, 3, weights, w)
+	})
+
+	if allocs != 0 {
+		t.Errorf("%v != %v", allocs, 0)
+	}
+
+	iweights := [][]int{{1, 5}, {1}, {1}, nil}
+
+	allocs = testing.AllocsPerRun(10, func() {
+		path, _ = g.ShortestPathInt(path, 0, 3, iweights, w)
+	})
+
+	if allocs != 0 {
+		t.Errorf("%v != %v", allocs, 0)
+	}
+}
+
+func TestGraphBellmanFordPath(t *testing.T) {
+	type E = weightedEdge
+
+	data := []struct {
+		size     int
+		edges    []E
+		u, v     int
+		path     []int
+		dist     float64
+		negCycle bool
+	}{
+		// CLRS Figure 24.4
+		{
+			size: 5,
+			edges: []E{
+				{0, 1, 6}, {0, 3, 7},
+				{1, 2, 5}, {1, 3, 8}, {1, 4, -4},
+				{2, 1, -2},
+				{3, 2, -3}, {3, 4, 9},
+				{4, 0, 2}, {4, 2, 7},
+			},
+			u:    0,
+			v:    4,
+			path: []int{0, 3, 2, 1, 4},
+			dist: -2,
+		},
+		// Negative edges on a DAG
+		{
+			size:  4,
+			edges: []E{{0, 1, 2}, {0, 2, 5}, {1, 3, 4}, {2, 3, -4}},
+			u:     0,
+			v:     3,
+			path:  []int{0, 2, 3},
+			dist:  1,
+		},
+		// No path
+		{
+			size:  3,
+			edges: []E{{0, 1, -1}, {2, 0, 1}},
+			u:     0,
+			v:     2,
+			path:  []int{},
+			dist:  math.Inf(1),
+		},
+		// Non-negative cycle
+		{
+			size:  3,
+			edges: []E{{0, 1, -1}, {1, 2, -1}, {2, 0, 3}},
+			u:     0,
+			v:     0,
+			path:  []int{0, 1, 2, 0},
+			dist:  1,
+		},
+		// Negative cycle that does not include u or v
+		{
+			size:     5,
+			edges:    []E{{0, 1, 1}, {1, 2, 1}, {2, 3, -3}, {3, 1, 1}, {0, 4, 1}},
+			u:        0,
+			v:        4,
+			path:     []int{1, 2, 3, 1},
+			dist:     -1,
+			negCycle: true,
+		},
+		// Negative self-loop
+		{
+			size:     2,
+			edges:    []E{{0, 1, 1}, {1, 1, -1}},
+			u:        0,
+			v:        1,
+			path:     []int{1, 1},
+			dist:     -1,
+			negCycle: true,
+		},
+		// Negative cycle through u
+		{
+			size:     2,
+			edges:    []E{{0, 1, 1}, {1, 0, -2}},
+			u:        0,
+			v:        0,
+			path:     []int{0, 1, 0},
+			dist:     -1,
+			negCycle: true,
+		},
+	}
+
+	w := NewWorkspace(0)
+
+	for _, row := range data {
+		g, weights := makeWeightedGraph(row.size, row.edges)
+
+		path, dist, negCycle := g.BellmanFordPath([]int{}, row.u, row.v, weights, w)
+
+		if negCycle != row.negCycle {
+			t.Errorf("%v != %v", negCycle, row.negCycle)
+		}
+		if negCycle {
+			// Any rotation of the expected cycle is acceptable
+			if !isRotation(path, row.path) {
+				t.Errorf("%v is not a rotation of %v", path, row.path)
+			}
+		} else if !reflect.DeepEqual(path, row.path) {
+			t.Errorf("%v != %v", path, row.path)
+		}
+		if dist != row.dist {
+			t.Errorf("%v != %v", dist, row.dist)
+		}
+	}
+}
+
+// isRotation returns true if the closed walks a and b visit the same
+// vertices in the same cyclic order.
+func isRotation(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	} else if len(a) == 0 {
+		return true
+	}
+
+	n := len(a) - 1
+
+	for offset := 0; offset < n; offset++ {
+		match := true
+		for i := 0; i < n; i++ {
+			if a[(i+offset)%n] != b[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+// An EdgeKind classifies an edge that is examined during a traversal.
+type EdgeKind int
+
+const (
+	TreeEdge    EdgeKind = iota // Edge to an undiscovered vertex
+	BackEdge                    // Edge to an ancestor on the DFS path, including self edges
+	ForwardEdge                 // Edge to a finished descendant in the DFS forest
+	CrossEdge                   // Any other edge
+)
+
+// A VisitAction is returned by the callbacks of a Visitor to control a
+// traversal.
+type VisitAction int
+
+const (
+	// Continue the traversal.
+	Continue VisitAction = iota
+
+	// Prune the traversal below the current vertex or edge. When returned
+	// from Discover, the edges of the vertex are not explored. When
+	// returned from Edge with a TreeEdge, the head is not discovered
+	// through this edge. Otherwise, Prune is equivalent to Continue.
+	Prune
+
+	// Stop the traversal immediately.
+	Stop
+)
+
+// A Visitor receives the events of a BFS or DFS traversal. Nil callbacks are
+// ignored.
+type Visitor struct {
+	// Discover is called when a vertex is first reached.
+	Discover func(v int) VisitAction
+
+	// Edge is called for every edge (u, v) that is examined, before v is
+	// discovered through it.
+	Edge func(u, v int, kind EdgeKind) VisitAction
+
+	// Finish is called after all edges of a vertex have been examined.
+	Finish func(v int) VisitAction
+}
+
+func (vis *Visitor) discover(v int) VisitAction {
+	if vis.Discover == nil {
+		return Continue
+	}
+	return vis.Discover(v)
+}
+
+func (vis *Visitor) edge(u, v int, kind EdgeKind) VisitAction {
+	if vis.Edge == nil {
+		return Continue
+	}
+	return vis.Edge(u, v, kind)
+}
+
+func (vis *Visitor) finish(v int) VisitAction {
+	if vis.Finish == nil {
+		return Continue
+	}
+	return vis.Finish(v)
+}
+
+// BFS traverses the graph in breadth-first order from each vertex of sources
+// that has not yet been discovered, or from every vertex in order if sources
+// is nil, and reports events to vis. Returns false if the traversal was
+// stopped by a callback.
+//
+// A breadth-first search does not track ancestry, so every edge to a
+// discovered vertex is reported as a CrossEdge.
+func (g Graph) BFS(sources []int, vis *Visitor, w *Workspace) bool {
+	return BFS(g, sources, vis, w)
+}
+
+// DFS traverses the graph in depth-first order from each vertex of sources
+// that has not yet been discovered, or from every vertex in order if sources
+// is nil, and reports events to vis. Returns false if the traversal was
+// stopped by a callback.
+//
+// The traversal is iterative, so it is safe for deep graphs. Vertices are
+// discovered in the same order as a recursive implementation, and every edge
+// is classified with respect to the resulting DFS forest.
+func (g Graph) DFS(sources []int, vis *Visitor, w *Workspace) bool {
+	return DFS(g, sources, vis, w)
+}
+
+// traversalRoots returns the number of traversal roots: len(sources), or the
+// number of vertices if sources is nil.
+func traversalRoots(sources []int, n int) int {
+	if sources == nil {
+		return n
+	}
+	return len(sources)
+}
+
+// BFS is like (Graph).BFS, but accepts any Adjacency.
+func BFS[A Adjacency](g A, sources []int, vis *Visitor, w *Workspace) bool {
+	n := g.Len()
+	w.prepare(n, 0)
+
+	discovered := w.makeBitsliceN(1, wA)[0] // |V|  · Bitslice of vertex -> discovered?
+	queue := w.makeQueue(wB)                // |V|w · BFS queue
+
+	for i := 0; i < traversalRoots(sources, n); i++ {
+		s := i
+		if sources != nil {
+			s = sources[i]
+		}
+
+		if discovered.Get(s) {
+			continue
+		}
+
+		discovered.Set(s)
+
+		switch vis.discover(s) {
+		case Stop:
+			return false
+		case Prune:
+			if vis.finish(s) == Stop {
+				return false
+			}
+			continue
+		}
+
+		queue.Enqueue(s)
+
+		for queue.Len() > 0 {
+			u := queue.Dequeue()
+
+			for _, v := range g.Neighbors(u) {
+				if discovered.Get(v) {
+					if vis.edge(u, v, CrossEdge) == Stop {
+						return false
+					}
+					continue
+				}
+
+				switch vis.edge(u, v, TreeEdge) {
+				case Stop:
+					return false
+				case Prune:
+					continue
+				}
+
+				discovered.Set(v)
+
+				switch vis.discover(v) {
+				case Stop:
+					return false
+				case Prune:
+					if vis.finish(v) == Stop {
+						return false
+					}
+					continue
+				}
+
+				queue.Enqueue(v)
+			}
+
+			if vis.finish(u) == Stop {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DFS is like (Graph).DFS, but accepts any Adjacency.
+func DFS[A Adjacency](g A, sources []int, vis *Visitor, w *Workspace) bool {
+	n := g.Len()
+	w.prepare(n, wANeg|wC)
+
+	disc := w.a              // |V|w · Slice of vertex -> discovery index
+	stack := w.makeStack(wB) // |V|w · DFS stack of active vertices
+	next := w.c              // |V|w · Slice of vertex -> index of next edge, or undefined if finished
+
+	time := 0
+
+	// visit discovers v and pushes it on the stack unless it is pruned.
+	visit := func(v int) bool {
+		disc[v] = time
+		time++
+
+		switch vis.discover(v) {
+		case Stop:
+			return false
+		case Prune:
+			next[v] = undefined
+			return vis.finish(v) != Stop
+		}
+
+		stack.Push(v)
+		return true
+	}
+
+	for i := 0; i < traversalRoots(sources, n); i++ {
+		s := i
+		if sources != nil {
+			s = sources[i]
+		}
+
+		if disc[s] != undefined {
+			continue
+		}
+
+		if !visit(s) {
+			return false
+		}
+
+		for stack.Len() > 0 {
+			u := stack.Peek()
+			edges := g.Neighbors(u)
+
+			if next[u] == len(edges) {
+				stack.Pop()
+				next[u] = undefined
+				if vis.finish(u) == Stop {
+					return false
+				}
+				continue
+			}
+
+			v := edges[next[u]]
+			next[u]++
+
+			var kind EdgeKind
+
+			switch {
+			case disc[v] == undefined:
+				kind = TreeEdge
+			case next[v] != undefined:
+				kind = BackEdge
+			case disc[v] > disc[u]:
+				kind = ForwardEdge
+			default:
+				kind = CrossEdge
+			}
+
+			switch vis.edge(u, v, kind) {
+			case Stop:
+				return false
+			case Prune:
+				continue
+			}
+
+			if kind == TreeEdge && !visit(v) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
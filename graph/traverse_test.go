@@ -0,0 +1,270 @@
+// Copyright (c) 2018 Sung Pae <self@sungpae.com>
+// Distributed under the MIT license.
+// http://www.opensource.org/licenses/mit-license.php
+
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// recordingVisitor returns a Visitor that appends every event to log.
+func recordingVisitor(log *[]string) *Visitor {
+	kinds := []string{"tree", "back", "forward", "cross"}
+
+	return &Visitor{
+		Discover: func(v int) VisitAction {
+			*log = append(*log, fmt.Sprint("discover ", v))
+			return Continue
+		},
+		Edge: func(u, v int, kind EdgeKind) VisitAction {
+			*log = append(*log, fmt.Sprint(kinds[kind], " ", u, " ", v))
+			return Continue
+		},
+		Finish: func(v int) VisitAction {
+			*log = append(*log, fmt.Sprint("finish ", v))
+			return Continue
+		},
+	}
+}
+
+// recursiveDFS is a reference implementation of DFS.
+func recursiveDFS(g Graph, log *[]string) {
+	kinds := []string{"tree", "back", "forward", "cross"}
+	disc := make([]int, len(g))
+	active := make([]bool, len(g))
+	for i := range disc {
+		disc[i] = -1
+	}
+	time := 0
+
+	var visit func(u int)
+	visit = func(u int) {
+		disc[u] = time
+		time++
+		active[u] = true
+		*log = append(*log, fmt.Sprint("discover ", u))
+
+		for _, v := range g[u] {
+			kind := CrossEdge
+			switch {
+			case disc[v] == -1:
+				kind = TreeEdge
+			case active[v]:
+				kind = BackEdge
+			case disc[v] > disc[u]:
+				kind = ForwardEdge
+			}
+
+			*log = append(*log, fmt.Sprint(kinds[kind], " ", u, " ", v))
+
+			if kind == TreeEdge {
+				visit(v)
+			}
+		}
+
+		active[u] = false
+		*log = append(*log, fmt.Sprint("finish ", u))
+	}
+
+	for u := range g {
+		if disc[u] == -1 {
+			visit(u)
+		}
+	}
+}
+
+func TestGraphDFS(t *testing.T) {
+	g := Graph{{1, 2}, {2, 0}, {2}, {1}}
+	w := NewWorkspace(0)
+
+	var log []string
+	if !g.DFS(nil, recordingVisitor(&log), w) {
+		t.Errorf("%v != %v", false, true)
+	}
+
+	out := []string{
+		"discover 0",
+		"tree 0 1",
+		"discover 1",
+		"tree 1 2",
+		"discover 2",
+		"back 2 2",
+		"finish 2",
+		"back 1 0",
+		"finish 1",
+		"forward 0 2",
+		"finish 0",
+		"discover 3",
+		"cross 3 1",
+		"finish 3",
+	}
+
+	if !reflect.DeepEqual(log, out) {
+		t.Errorf("%v != %v", log, out)
+	}
+
+	// Sources
+	log = nil
+	g.DFS([]int{3, 2}, recordingVisitor(&log), w)
+	if log[0] != "discover 3" || len(log) != 14 {
+		t.Errorf("unexpected log: %v", log)
+	}
+}
+
+func TestGraphDFSRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	w := NewWorkspace(0)
+
+	for trial := 0; trial < 100; trial++ {
+		size := r.Intn(20)
+		g := make(Graph, size)
+		for i := 0; size > 0 && i < size*2; i++ {
+			g.AddEdge(r.Intn(size), r.Intn(size))
+		}
+
+		var log, want []string
+		g.DFS(nil, recordingVisitor(&log), w)
+		recursiveDFS(g, &want)
+
+		if !reflect.DeepEqual(log, want) {
+			t.Errorf("%v != %v", log, want)
+		}
+
+		// CSR agrees
+		var clog []string
+		NewCSR(g).DFS(nil, recordingVisitor(&clog), w)
+		if !reflect.DeepEqual(clog, log) {
+			t.Errorf("%v != %v", clog, log)
+		}
+	}
+}
+
+func TestGraphBFS(t *testing.T) {
+	g := Graph{{1, 2}, {3}, {3}, {0}, {}}
+	w := NewWorkspace(0)
+
+	var log []string
+	g.BFS([]int{0}, recordingVisitor(&log), w)
+
+	out := []string{
+		"discover 0",
+		"tree 0 1",
+		"discover 1",
+		"tree 0 2",
+		"discover 2",
+		"finish 0",
+		"tree 1 3",
+		"discover 3",
+		"finish 1",
+		"cross 2 3",
+		"finish 2",
+		"cross 3 0",
+		"finish 3",
+	}
+
+	if !reflect.DeepEqual(log, out) {
+		t.Errorf("%v != %v", log, out)
+	}
+
+	// All vertices
+	log = nil
+	g.BFS(nil, recordingVisitor(&log), w)
+	if log[len(log)-1] != "finish 4" {
+		t.Errorf("%v != %v", log[len(log)-1], "finish 4")
+	}
+}
+
+func TestTraversalControl(t *testing.T) {
+	// Path 0 -> 1 -> 2 -> 3, with a shortcut 0 -> 3
+	g := Graph{{1, 3}, {2}, {3}, {}}
+	w := NewWorkspace(0)
+
+	for _, traverse := range []func([]int, *Visitor, *Workspace) bool{g.BFS, g.DFS} {
+		// Stop on discovery of 2
+		var found []int
+		vis := &Visitor{Discover: func(v int) VisitAction {
+			found = append(found, v)
+			if v == 2 {
+				return Stop
+			}
+			return Continue
+		}}
+
+		if traverse([]int{0}, vis, w) {
+			t.Errorf("%v != %v", true, false)
+		}
+		if found[len(found)-1] != 2 {
+			t.Errorf("%v != %v", found[len(found)-1], 2)
+		}
+
+		// Prune at 1: 2 is never discovered
+		found = nil
+		vis = &Visitor{Discover: func(v int) VisitAction {
+			found = append(found, v)
+			if v == 1 {
+				return Prune
+			}
+			return Continue
+		}}
+
+		if !traverse([]int{0}, vis, w) {
+			t.Errorf("%v != %v", false, true)
+		}
+		if len(found) != 3 {
+			t.Errorf("%v != %v", found, []int{0, 1, 3})
+		}
+
+		// Prune the tree edge 0 -> 3: 3 is discovered through 2
+		var finished []int
+		vis = &Visitor{
+			Edge: func(u, v int, kind EdgeKind) VisitAction {
+				if u == 0 && v == 3 {
+					return Prune
+				}
+				return Continue
+			},
+			Finish: func(v int) VisitAction {
+				finished = append(finished, v)
+				return Continue
+			},
+		}
+
+		traverse([]int{0}, vis, w)
+		if len(finished) != 4 {
+			t.Errorf("%v != %v", len(finished), 4)
+		}
+	}
+}
+
+func TestGraphDFSDeep(t *testing.T) {
+	const size = 1 << 18
+
+	g := make(Graph, size)
+	for u := 0; u < size-1; u++ {
+		g.AddEdge(u, u+1)
+	}
+
+	depth, max := 0, 0
+	vis := &Visitor{
+		Discover: func(int) VisitAction {
+			if depth++; depth > max {
+				max = depth
+			}
+			return Continue
+		},
+		Finish: func(int) VisitAction {
+			depth--
+			return Continue
+		},
+	}
+
+	g.DFS(nil, vis, NewWorkspace(size))
+
+	if max != size {
+		t.Errorf("%v != %v", max, size)
+	}
+}
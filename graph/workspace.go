@@ -5,6 +5,7 @@
 package graph
 
 import (
+	"math"
 	"unsafe"
 
 	"github.com/guns/golibs/bitslice"
@@ -15,6 +16,8 @@ import (
 type Workspace struct {
 	len, cap int // Logical len/cap, not buffer len/cap
 	a, b, c  []int
+	d        []int     // Allocated on demand for algorithms that need a fourth int field
+	f, e     []float64 // Allocated on demand for weighted algorithms
 }
 
 // NewWorkspace returns a new Workspace for a Graph of a given size.
@@ -42,6 +45,15 @@ func (w *Workspace) resize(size int) bool {
 		w.a = w.a[:size]
 		w.b = w.b[:size]
 		w.c = w.c[:size]
+		if w.d != nil {
+			w.d = w.d[:size]
+		}
+		if w.f != nil {
+			w.f = w.f[:size]
+		}
+		if w.e != nil {
+			w.e = w.e[:size]
+		}
 		return false
 	}
 
@@ -59,6 +71,12 @@ const (
 	wANeg                            // Fill (*Workspace).a with undefined
 	wBNeg                            // Fill (*Workspace).b with undefined
 	wCNeg                            // Fill (*Workspace).c with undefined
+	wF                               // Allocate or reset (*Workspace).f
+	wFInf                            // Allocate (*Workspace).f and fill with +Inf
+	wE                               // Allocate or reset (*Workspace).e
+	wEInf                            // Allocate (*Workspace).e and fill with +Inf
+	wD                               // Allocate or reset (*Workspace).d
+	wDNeg                            // Allocate (*Workspace).d and fill with undefined
 )
 
 func (w *Workspace) selectSlice(field workspaceField) []int {
@@ -138,6 +156,57 @@ func (w *Workspace) makeSharedStacks(fields workspaceField) (autoPromotingStack,
 	return *newAutoPromotingStack(buf), *newNonPromotingStack(buf)
 }
 
+// makeUnionFind returns an IntUnionFind of the vertices of the graph, backed
+// by the given fields. The fields parameter must specify two contiguous
+// internal fields.
+func (w *Workspace) makeUnionFind(fields workspaceField) impl.IntUnionFind {
+	var buf []int
+
+	switch fields {
+	case wA | wB:
+		buf = w.a[:w.len*2]
+	case wB | wC:
+		buf = w.b[:w.len*2]
+	}
+
+	return *impl.NewIntUnionFindWithBuffer(buf)
+}
+
+// makeHeap returns an empty indexedHeap keyed by (*Workspace).f. The fields
+// parameter must specify two internal fields; the lower field backs the heap
+// and the higher field backs the vertex -> heap position index.
+func (w *Workspace) makeHeap(fields workspaceField) indexedHeap[float64] {
+	a, pos := w.selectHeapFields(fields)
+	return *newIndexedHeap(a, pos, w.f)
+}
+
+// makeIntHeap is like makeHeap, but returns an indexedHeap keyed by
+// (*Workspace).d.
+func (w *Workspace) makeIntHeap(fields workspaceField) indexedHeap[int] {
+	a, pos := w.selectHeapFields(fields)
+	return *newIndexedHeap(a, pos, w.d)
+}
+
+// selectHeapFields returns the heap and position slices of an indexedHeap
+// backed by the given fields, after filling the position slice with
+// undefined.
+func (w *Workspace) selectHeapFields(fields workspaceField) (a, pos []int) {
+	switch fields {
+	case wA | wB:
+		a, pos = w.a, w.b
+	case wA | wC:
+		a, pos = w.a, w.c
+	case wB | wC:
+		a, pos = w.b, w.c
+	}
+
+	for i := range pos {
+		pos[i] = undefined
+	}
+
+	return a, pos
+}
+
 // reset a Workspace. The fields parameter is a bitfield of workspaceField
 // values that specify which fields to reset.
 func (w *Workspace) reset(fields workspaceField) {
@@ -174,6 +243,77 @@ func (w *Workspace) reset(fields workspaceField) {
 			w.c[i] = undefined
 		}
 	}
+
+	if fields&(wD|wDNeg) > 0 {
+		w.d = w.resetInts(w.d, fields&wDNeg > 0)
+	}
+
+	if fields&(wF|wFInf) > 0 {
+		w.f = w.resetFloats(w.f, fields&wFInf > 0)
+	}
+
+	if fields&(wE|wEInf) > 0 {
+		w.e = w.resetFloats(w.e, fields&wEInf > 0)
+	}
+}
+
+// resetInts allocates a if necessary, and fills it with zero or undefined.
+func (w *Workspace) resetInts(a []int, neg bool) []int {
+	if cap(a) < w.len {
+		a = make([]int, w.len, w.cap)
+	}
+	a = a[:w.len]
+
+	x := 0
+	if neg {
+		x = undefined
+	}
+	for i := range a {
+		a[i] = x
+	}
+
+	return a
+}
+
+// resetFloats allocates f if necessary, and fills it with zero or +Inf.
+func (w *Workspace) resetFloats(f []float64, inf bool) []float64 {
+	if cap(f) < w.len {
+		f = make([]float64, w.len, w.cap)
+	}
+	f = f[:w.len]
+
+	x := 0.0
+	if inf {
+		x = math.Inf(1)
+	}
+	for i := range f {
+		f[i] = x
+	}
+
+	return f
+}
+
+// clear zeroes the entire memory of a Workspace, including the memory beyond
+// its current size.
+func (w *Workspace) clear() {
+	for _, buf := range [][]int{w.a, w.b, w.c} {
+		buf = buf[:w.cap]
+		for i := range buf {
+			buf[i] = 0
+		}
+	}
+
+	d := w.d[:cap(w.d)]
+	for i := range d {
+		d[i] = 0
+	}
+
+	for _, buf := range [][]float64{w.f, w.e} {
+		buf = buf[:cap(buf)]
+		for i := range buf {
+			buf[i] = 0
+		}
+	}
 }
 
 // prepare a Workspace for a Graph of a given size. The fields parameter is a
@@ -5,6 +5,7 @@
 package graph
 
 import (
+	"math"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -186,4 +187,38 @@ func TestWorkspace(t *testing.T) {
 	if !reflect.DeepEqual(aps.s, nps.s) {
 		t.Errorf("%v != %v", aps.s, nps.s)
 	}
+
+	// Test float slice
+
+	w.prepare(4, wFInf)
+	inf := math.Inf(1)
+
+	if !reflect.DeepEqual(w.f, []float64{inf, inf, inf, inf}) {
+		t.Errorf("%v != %v", w.f, []float64{inf, inf, inf, inf})
+	}
+
+	w.prepare(6, wF)
+
+	if !reflect.DeepEqual(w.f, make([]float64, 6)) {
+		t.Errorf("%v != %v", w.f, make([]float64, 6))
+	}
+
+	// Test on-demand int slice
+
+	w.prepare(4, wD)
+	for i := range w.d {
+		w.d[i] = i + 1
+	}
+
+	w.prepare(6, wD)
+
+	if !reflect.DeepEqual(w.d, make([]int, 6)) {
+		t.Errorf("%v != %v", w.d, make([]int, 6))
+	}
+
+	w.prepare(3, wDNeg)
+
+	if !reflect.DeepEqual(w.d, []int{undefined, undefined, undefined}) {
+		t.Errorf("%v != %v", w.d, []int{undefined, undefined, undefined})
+	}
 }